@@ -0,0 +1,56 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import "fmt"
+
+// The aggregate functions return the Expression of the function call
+// on the column, which is quoted by the dialect, so they can be used as
+// the selected columns and compared with the values by CompareExpr.
+// For example,
+//
+//	Selects("area").SelectExpr(Count("*"), "total").From("users").GroupBy("area").
+//		HavingCondition(CompareExpr(Count("*"), ">", 10), CompareExpr(Avg("age"), "<=", 30))
+//	// SELECT `area`, COUNT(*) AS `total` FROM `users` GROUP BY `area`
+//	//   HAVING COUNT(*)>? AND AVG(`age`)<=?
+
+type aggregateExpr struct {
+	format string
+	column string
+}
+
+func (e aggregateExpr) Build(ab *ArgsBuilder) string {
+	return fmt.Sprintf(e.format, ab.Quote(e.column))
+}
+
+// Count returns the expression "COUNT(column)".
+func Count(column string) Expression { return aggregateExpr{"COUNT(%s)", column} }
+
+// CountDistinct returns the expression "COUNT(DISTINCT column)".
+func CountDistinct(column string) Expression {
+	return aggregateExpr{"COUNT(DISTINCT %s)", column}
+}
+
+// Sum returns the expression "SUM(column)".
+func Sum(column string) Expression { return aggregateExpr{"SUM(%s)", column} }
+
+// Avg returns the expression "AVG(column)".
+func Avg(column string) Expression { return aggregateExpr{"AVG(%s)", column} }
+
+// Max returns the expression "MAX(column)".
+func Max(column string) Expression { return aggregateExpr{"MAX(%s)", column} }
+
+// Min returns the expression "MIN(column)".
+func Min(column string) Expression { return aggregateExpr{"MIN(%s)", column} }
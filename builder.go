@@ -20,6 +20,52 @@ type Builder interface {
 	Build() (sql string, args []interface{})
 }
 
+// NestedBuilder is a Builder which can be built into the arguments of another
+// statement, such as the sub-query, so that the placeholders of the arguments
+// are numbered consecutively.
+type NestedBuilder interface {
+	Builder
+
+	// BuildNested builds the statement with the dialect of ab, adds
+	// the arguments into ab, and returns the sql without being intercepted.
+	BuildNested(ab *ArgsBuilder) string
+}
+
+// buildNested builds the builder b into ab.
+//
+// If b has not implemented the interface NestedBuilder, it is built by itself,
+// which only supports the dialect whose placeholders are not numbered, such as
+// MySQL and SQLite, or the builder without the arguments.
+func buildNested(ab *ArgsBuilder, b Builder) string {
+	if nb, ok := b.(NestedBuilder); ok {
+		return nb.BuildNested(ab)
+	}
+
+	sql, args := b.Build()
+	if len(args) > 0 && ab.Placeholder(1) != ab.Placeholder(2) {
+		panic(buildErrorf("NestedBuilder", ErrInvalidStatement,
+			"cannot renumber the placeholders of the nested %T", b))
+	}
+	ab.args = append(ab.args, args...)
+	return sql
+}
+
+// cloneBuilder returns the clone of the nested builder b if it is one of
+// the mutable builders of this package, or b itself.
+func cloneBuilder(b Builder) Builder {
+	switch v := b.(type) {
+	case *SelectBuilder:
+		if !v.immutable {
+			return v.Clone()
+		}
+	case *CompoundBuilder:
+		if !v.immutable {
+			return v.Clone()
+		}
+	}
+	return b
+}
+
 // Interceptor is used to intercept the built sql result and return a new one.
 type Interceptor func(sql string, args []interface{}) (string, []interface{})
 
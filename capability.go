@@ -0,0 +1,390 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import "fmt"
+
+// CapableDialect is an optional interface implemented by the Dialect
+// to report which SQL features it supports.
+//
+// The builders consult it to translate a construct for the dialect,
+// or report an UnsupportedError instead of producing the SQL statement
+// that the server rejects.
+type CapableDialect interface {
+	Dialect
+
+	// Capabilities returns the SQL features supported by the dialect.
+	Capabilities() Capabilities
+}
+
+// UpsertStyle represents the UPSERT syntax supported by the dialect.
+type UpsertStyle int
+
+// Predefine some UPSERT styles.
+const (
+	// UpsertNone means that the dialect does not support UPSERT.
+	UpsertNone UpsertStyle = iota
+
+	// UpsertOnDuplicateKey represents "ON DUPLICATE KEY UPDATE" and
+	// "INSERT IGNORE", such as MySQL.
+	UpsertOnDuplicateKey
+
+	// UpsertOnConflict represents "ON CONFLICT ... DO UPDATE SET ..."
+	// and "ON CONFLICT DO NOTHING", such as PostgreSQL and SQLite.
+	UpsertOnConflict
+)
+
+// ReturningStyle represents the syntax to return the affected rows
+// supported by the dialect.
+type ReturningStyle int
+
+// Predefine some RETURNING styles.
+const (
+	// ReturningNone means that the dialect does not support RETURNING.
+	ReturningNone ReturningStyle = iota
+
+	// ReturningClause represents "RETURNING column, ...", which is placed
+	// at the end of the statement, such as PostgreSQL and SQLite.
+	ReturningClause
+
+	// ReturningOutput represents "OUTPUT INSERTED.column, ...", such as SQL Server.
+	ReturningOutput
+)
+
+// UpdateStyle represents how the dialect supports other tables in UPDATE.
+type UpdateStyle int
+
+// Predefine some UPDATE styles.
+const (
+	// UpdateSingle means that UPDATE only supports a single table
+	// without FROM and JOIN, such as Oracle.
+	UpdateSingle UpdateStyle = iota
+
+	// UpdateGeneric builds the tables, JOIN and FROM as they are given.
+	UpdateGeneric
+
+	// UpdateJoin represents "UPDATE t1, t2 JOIN t3 ON ... SET ...",
+	// such as MySQL. The FROM tables are appended to the updated tables.
+	UpdateJoin
+
+	// UpdateFrom represents "UPDATE t1 SET ... FROM t2", which does not
+	// support JOIN, such as PostgreSQL and SQLite.
+	UpdateFrom
+
+	// UpdateFromJoin represents "UPDATE a SET ... FROM t1 AS a JOIN t2 ON ...",
+	// such as SQL Server.
+	UpdateFromJoin
+)
+
+// DeleteStyle represents how the dialect supports other tables in DELETE.
+type DeleteStyle int
+
+// Predefine some DELETE styles.
+const (
+	// DeleteSingle means that DELETE only supports a single table
+	// without USING and JOIN, such as SQLite and Oracle.
+	DeleteSingle DeleteStyle = iota
+
+	// DeleteGeneric builds the tables and JOIN as they are given.
+	DeleteGeneric
+
+	// DeleteJoin represents "DELETE t1, t2 FROM t1 JOIN t2 ON ...", such as MySQL.
+	DeleteJoin
+
+	// DeleteUsing represents "DELETE FROM t1 USING t2", which does not
+	// support JOIN, such as PostgreSQL.
+	DeleteUsing
+
+	// DeleteFromJoin represents "DELETE t1 FROM t1 JOIN t2 ON ...", which
+	// only supports a single deleted table, such as SQL Server.
+	DeleteFromJoin
+)
+
+// Capabilities represents the SQL features supported by a dialect.
+type Capabilities struct {
+	Upsert    UpsertStyle
+	Returning ReturningStyle
+	Update    UpdateStyle
+	Delete    DeleteStyle
+
+	// Replace reports whether the dialect supports "REPLACE INTO".
+	Replace bool
+
+	// RightJoin and FullJoin report whether the dialect supports
+	// "RIGHT JOIN" and "FULL JOIN".
+	RightJoin bool
+	FullJoin  bool
+
+	// JoinUsing and NaturalJoin report whether the dialect supports
+	// "JOIN ... USING (columns)" and "NATURAL JOIN".
+	JoinUsing   bool
+	NaturalJoin bool
+
+	// BooleanLiteral reports whether the dialect supports the boolean
+	// literals TRUE and FALSE. If not, use 1 and 0 instead.
+	BooleanLiteral bool
+
+	// TableAliasAS reports whether the dialect supports "AS"
+	// between the table and its alias.
+	TableAliasAS bool
+
+	// MultiRowValues reports whether the dialect supports the multi-row
+	// VALUES in INSERT. If not, use "INSERT ALL" instead.
+	MultiRowValues bool
+
+	// OffsetRequiresOrderBy reports whether OFFSET must follow ORDER BY.
+	OffsetRequiresOrderBy bool
+
+	// LockForUpdate and LockForShare report whether the dialect supports
+	// the row locking clauses "FOR UPDATE" and "FOR SHARE" after LIMIT.
+	LockForUpdate bool
+	LockForShare  bool
+
+	// LockWithLimit reports whether the row locking clause may be used
+	// with LIMIT or OFFSET, which is rejected by Oracle.
+	LockWithLimit bool
+
+	// RowValueCompare reports whether the dialect supports the comparison
+	// of the row values, such as "(a, b) > (?, ?)".
+	RowValueCompare bool
+
+	// ExceptMinus reports whether the dialect uses MINUS instead of EXCEPT.
+	ExceptMinus bool
+
+	// IntersectExcept reports whether the dialect supports INTERSECT and
+	// EXCEPT (or MINUS) in the compound SELECT.
+	IntersectExcept bool
+
+	// CompoundMemberLimit reports whether the member query of the compound
+	// SELECT may have ORDER BY or LIMIT, which is enclosed in parentheses.
+	CompoundMemberLimit bool
+
+	// NamedWindow reports whether the dialect supports the named window
+	// definition "WINDOW name AS (...)" in SELECT.
+	NamedWindow bool
+
+	// RecursiveKeyword reports whether the recursive common table expression
+	// requires "WITH RECURSIVE". If not, use "WITH" instead.
+	RecursiveKeyword bool
+
+	// MaxArgs is the maximum number of the placeholders in a statement,
+	// and MaxRows is the maximum number of the rows in a multi-row VALUES,
+	// which are used to split the inserted rows into several statements.
+	// 0 means no limit.
+	MaxArgs int
+	MaxRows int
+}
+
+// Bool returns the boolean literal of b supported by the dialect.
+func (c Capabilities) Bool(b bool) string {
+	switch {
+	case c.BooleanLiteral && b:
+		return "TRUE"
+	case c.BooleanLiteral:
+		return "FALSE"
+	case b:
+		return "1"
+	default:
+		return "0"
+	}
+}
+
+// GenericCapabilities is the capabilities of the dialect which has not
+// implemented the interface CapableDialect, which builds the statements
+// as they are given.
+var GenericCapabilities = Capabilities{
+	Upsert:              UpsertOnDuplicateKey,
+	Returning:           ReturningNone,
+	Update:              UpdateGeneric,
+	Delete:              DeleteGeneric,
+	Replace:             true,
+	RightJoin:           true,
+	FullJoin:            true,
+	JoinUsing:           true,
+	NaturalJoin:         true,
+	BooleanLiteral:      true,
+	TableAliasAS:        true,
+	MultiRowValues:      true,
+	LockForUpdate:       true,
+	LockForShare:        true,
+	LockWithLimit:       true,
+	RowValueCompare:     true,
+	IntersectExcept:     true,
+	CompoundMemberLimit: true,
+	NamedWindow:         true,
+	RecursiveKeyword:    true,
+}
+
+// GetCapabilities returns the capabilities of the dialect.
+//
+// If the dialect has not implemented the interface CapableDialect,
+// return GenericCapabilities instead.
+func GetCapabilities(dialect Dialect) Capabilities {
+	if d, ok := dialect.(CapableDialect); ok {
+		return d.Capabilities()
+	}
+	return GenericCapabilities
+}
+
+var capabilities = map[string]Capabilities{
+	// INTERSECT and EXCEPT require MySQL 8.0.31+. For the former versions,
+	// implement the interface CapableDialect to disable IntersectExcept.
+	mysqlDialect: {
+		Upsert:              UpsertOnDuplicateKey,
+		Returning:           ReturningNone,
+		Update:              UpdateJoin,
+		Delete:              DeleteJoin,
+		Replace:             true,
+		RightJoin:           true,
+		FullJoin:            false,
+		JoinUsing:           true,
+		NaturalJoin:         true,
+		BooleanLiteral:      true,
+		TableAliasAS:        true,
+		MultiRowValues:      true,
+		LockForUpdate:       true,
+		LockForShare:        true,
+		LockWithLimit:       true,
+		RowValueCompare:     true,
+		IntersectExcept:     true,
+		CompoundMemberLimit: true,
+		NamedWindow:         true,
+		RecursiveKeyword:    true,
+		MaxArgs:             65535,
+	},
+
+	// RETURNING requires SQLite 3.35.0+, UPDATE FROM requires 3.33.0+,
+	// and RIGHT and FULL JOIN require 3.39.0+. The limit of the placeholders
+	// is 999 before SQLite 3.32.0.
+	sqlite3Dialect: {
+		Upsert:              UpsertOnConflict,
+		Returning:           ReturningClause,
+		Update:              UpdateFrom,
+		Delete:              DeleteSingle,
+		Replace:             true,
+		RightJoin:           true,
+		FullJoin:            true,
+		JoinUsing:           true,
+		NaturalJoin:         true,
+		BooleanLiteral:      true,
+		TableAliasAS:        true,
+		MultiRowValues:      true,
+		LockForUpdate:       false,
+		LockForShare:        false,
+		LockWithLimit:       true,
+		RowValueCompare:     true,
+		IntersectExcept:     true,
+		CompoundMemberLimit: false,
+		NamedWindow:         true,
+		RecursiveKeyword:    true,
+		MaxArgs:             32766,
+	},
+
+	pqDialect: {
+		Upsert:              UpsertOnConflict,
+		Returning:           ReturningClause,
+		Update:              UpdateFrom,
+		Delete:              DeleteUsing,
+		Replace:             false,
+		RightJoin:           true,
+		FullJoin:            true,
+		JoinUsing:           true,
+		NaturalJoin:         true,
+		BooleanLiteral:      true,
+		TableAliasAS:        true,
+		MultiRowValues:      true,
+		LockForUpdate:       true,
+		LockForShare:        true,
+		LockWithLimit:       true,
+		RowValueCompare:     true,
+		IntersectExcept:     true,
+		CompoundMemberLimit: true,
+		NamedWindow:         true,
+		RecursiveKeyword:    true,
+		MaxArgs:             65535,
+	},
+
+	// SQL Server limits a multi-row VALUES to 1000 rows, and uses the table
+	// hints, such as "WITH (UPDLOCK)", instead of the row locking clauses.
+	// The named window requires SQL Server 2022+.
+	// The server supports 2100 parameters at most, but some are taken by
+	// the drivers based on sp_executesql, so reserve some for them.
+	mssqlDialect: {
+		Upsert:                UpsertNone,
+		Returning:             ReturningOutput,
+		Update:                UpdateFromJoin,
+		Delete:                DeleteFromJoin,
+		Replace:               false,
+		RightJoin:             true,
+		FullJoin:              true,
+		JoinUsing:             false,
+		NaturalJoin:           false,
+		BooleanLiteral:        false,
+		TableAliasAS:          true,
+		MultiRowValues:        true,
+		LockForUpdate:         false,
+		LockForShare:          false,
+		LockWithLimit:         true,
+		RowValueCompare:       false,
+		OffsetRequiresOrderBy: true,
+		IntersectExcept:       true,
+		CompoundMemberLimit:   true,
+		NamedWindow:           false,
+		RecursiveKeyword:      false,
+		MaxArgs:               2000,
+		MaxRows:               1000,
+	},
+
+	oracleDialect: {
+		Upsert:              UpsertNone,
+		Returning:           ReturningNone,
+		Update:              UpdateSingle,
+		Delete:              DeleteSingle,
+		Replace:             false,
+		RightJoin:           true,
+		FullJoin:            true,
+		JoinUsing:           true,
+		NaturalJoin:         true,
+		BooleanLiteral:      false,
+		TableAliasAS:        false,
+		MultiRowValues:      false,
+		LockForUpdate:       true,
+		LockForShare:        false,
+		LockWithLimit:       false,
+		RowValueCompare:     false,
+		ExceptMinus:         true,
+		IntersectExcept:     true,
+		CompoundMemberLimit: true,
+		NamedWindow:         false,
+		RecursiveKeyword:    false,
+		MaxArgs:             65535,
+	},
+}
+
+// UnsupportedError represents that the dialect does not support a SQL feature.
+type UnsupportedError struct {
+	Dialect string
+	Feature string
+}
+
+func unsupported(dialect Dialect, feature string) UnsupportedError {
+	return UnsupportedError{Dialect: dialect.Name(), Feature: feature}
+}
+
+// Error implements the interface error.
+func (e UnsupportedError) Error() string {
+	return fmt.Sprintf("sqlx: the sql dialect '%s' does not support %s",
+		e.Dialect, e.Feature)
+}
@@ -0,0 +1,175 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import "testing"
+
+func expectUnsupported(t *testing.T, name string, build func() string) {
+	defer func() {
+		if _, ok := recover().(UnsupportedError); !ok {
+			t.Errorf("%s: expected an UnsupportedError", name)
+		}
+	}()
+	build()
+}
+
+func TestCapabilitiesInsert(t *testing.T) {
+	ignore := Insert().IgnoreInto("table").Columns("c1")
+	if s := ignore.SetDialect(MySQL).String(); s != "INSERT IGNORE INTO `table` (`c1`) VALUES (?)" {
+		t.Errorf("unexpected sql '%s'", s)
+	}
+	if s := ignore.SetDialect(Postgres).String(); s != `INSERT INTO "table" ("c1") VALUES ($1) ON CONFLICT DO NOTHING` {
+		t.Errorf("unexpected sql '%s'", s)
+	}
+	expectUnsupported(t, "IgnoreInto", ignore.SetDialect(MSSQL).String)
+
+	replace := Insert().ReplaceInto("table").Columns("c1")
+	if s := replace.SetDialect(Sqlite3).String(); s != `REPLACE INTO "table" ("c1") VALUES (?)` {
+		t.Errorf("unexpected sql '%s'", s)
+	}
+	expectUnsupported(t, "ReplaceInto", replace.SetDialect(Postgres).String)
+
+	upsert := Insert().Into("table").Columns("c1").OnConflict("c1").DoNothing()
+	expectUnsupported(t, "UPSERT", upsert.SetDialect(Oracle).String)
+}
+
+func TestCapabilitiesUpdate(t *testing.T) {
+	update := Update().Table("table1", "A").From("table2", "B").
+		Set(ColumnEqual("A.c1", "B.c1")).Where(ColumnEqual("A.id", "B.id"))
+
+	expecteds := map[Dialect]string{
+		MySQL:    "UPDATE `table1` AS `A`, `table2` AS `B` SET `A`.`c1`=`B`.`c1` WHERE `A`.`id`=`B`.`id`",
+		Postgres: `UPDATE "table1" AS "A" SET "A"."c1"="B"."c1" FROM "table2" AS "B" WHERE "A"."id"="B"."id"`,
+		MSSQL:    `UPDATE [A] SET [A].[c1]=[B].[c1] FROM [table1] AS [A], [table2] AS [B] WHERE [A].[id]=[B].[id]`,
+	}
+	for dialect, expected := range expecteds {
+		if s := update.SetDialect(dialect).String(); s != expected {
+			t.Errorf("%s: expected '%s', got '%s'", dialect.Name(), expected, s)
+		}
+	}
+	expectUnsupported(t, "UPDATE FROM", update.SetDialect(Oracle).String)
+
+	join := Update().Table("table1", "A").JoinLeft("table2", "B", On("A.id", "B.id")).
+		Set(Assign("A.c1", 1))
+	if s := join.SetDialect(MSSQL).String(); s != `UPDATE [A] SET [A].[c1]=@p1 FROM [table1] AS [A] LEFT JOIN [table2] AS [B] ON [A].[id]=[B].[id]` {
+		t.Errorf("unexpected sql '%s'", s)
+	}
+	expectUnsupported(t, "UPDATE JOIN", join.SetDialect(Postgres).String)
+}
+
+func TestCapabilitiesDelete(t *testing.T) {
+	del := Delete().From("table1", "A").JoinLeft("table2", "B", On("A.id", "B.id")).
+		Where(IsNull("B.id"))
+	if s := del.SetDialect(MySQL).String(); s != "DELETE `A` FROM `table1` AS `A` LEFT JOIN `table2` AS `B` ON `A`.`id`=`B`.`id` WHERE `B`.`id` IS NULL" {
+		t.Errorf("unexpected sql '%s'", s)
+	}
+	expectUnsupported(t, "DELETE JOIN", del.SetDialect(Postgres).String)
+	expectUnsupported(t, "DELETE JOIN", del.SetDialect(Sqlite3).String)
+
+	using := Delete("A").From("table1", "A").From("table2", "B").
+		Where(ColumnEqual("A.id", "B.id"))
+	if s := using.SetDialect(Postgres).String(); s != `DELETE FROM "table1" AS "A" USING "table2" AS "B" WHERE "A"."id"="B"."id"` {
+		t.Errorf("unexpected sql '%s'", s)
+	}
+	if s := using.SetDialect(MSSQL).String(); s != `DELETE [A] FROM [table1] AS [A], [table2] AS [B] WHERE [A].[id]=[B].[id]` {
+		t.Errorf("unexpected sql '%s'", s)
+	}
+	expectUnsupported(t, "DELETE USING", using.SetDialect(Oracle).String)
+}
+
+func TestCapabilitiesJoin(t *testing.T) {
+	sel := Select("*").From("table1").JoinFull("table2", "", On("table1.id", "table2.id"))
+	if s := sel.SetDialect(Postgres).String(); s != `SELECT * FROM "table1" FULL JOIN "table2" ON "table1"."id"="table2"."id"` {
+		t.Errorf("unexpected sql '%s'", s)
+	}
+	expectUnsupported(t, "FULL JOIN", sel.SetDialect(MySQL).String)
+
+	sel = Select("*").From("table1").JoinUsing("table2", "", "id")
+	if s := sel.SetDialect(Oracle).String(); s != `SELECT * FROM "table1" JOIN "table2" USING ("id")` {
+		t.Errorf("unexpected sql '%s'", s)
+	}
+	expectUnsupported(t, "JOIN USING", sel.SetDialect(MSSQL).String)
+
+	sel = Select("*").From("table1").JoinNatural("table2", "")
+	expectUnsupported(t, "NATURAL JOIN", sel.SetDialect(MSSQL).String)
+}
+
+func TestCapabilitiesLock(t *testing.T) {
+	sel := Select("*").From("table").Where(Equal("id", 1)).ForShare().NoWait()
+	if s := sel.SetDialect(Postgres).String(); s != `SELECT * FROM "table" WHERE "id"=$1 FOR SHARE NOWAIT` {
+		t.Errorf("unexpected sql '%s'", s)
+	}
+	expectUnsupported(t, "FOR SHARE", sel.SetDialect(Oracle).String)
+	expectUnsupported(t, "FOR UPDATE", sel.ForUpdate().SetDialect(Sqlite3).String)
+	expectUnsupported(t, "FOR UPDATE", sel.SetDialect(MSSQL).String)
+
+	sel = Select("*").From("table").Where(Equal("id", 1)).ForUpdate()
+	if s := sel.SetDialect(Oracle).String(); s != `SELECT * FROM "table" WHERE "id"=:1 FOR UPDATE` {
+		t.Errorf("unexpected sql '%s'", s)
+	}
+	expectUnsupported(t, "FOR UPDATE with row limiting", sel.Limit(10).SetDialect(Oracle).String)
+}
+
+func TestCapabilitiesBool(t *testing.T) {
+	sel := Select("*").From("table").Where(IsTrue("c1"), IsFalse("c2"))
+	if s := sel.SetDialect(Postgres).String(); s != `SELECT * FROM "table" WHERE ("c1"=TRUE AND "c2"=FALSE)` {
+		t.Errorf("unexpected sql '%s'", s)
+	}
+	if s := sel.SetDialect(MSSQL).String(); s != `SELECT * FROM [table] WHERE ([c1]=1 AND [c2]=0)` {
+		t.Errorf("unexpected sql '%s'", s)
+	}
+}
+
+// mysql57Dialect is the MySQL dialect before 8.0.31, which does not support
+// INTERSECT and EXCEPT.
+type mysql57Dialect struct{ Dialect }
+
+func (d mysql57Dialect) Capabilities() Capabilities {
+	caps := GetCapabilities(d.Dialect)
+	caps.IntersectExcept = false
+	return caps
+}
+
+func TestCapabilitiesCompound(t *testing.T) {
+	q1 := Selects("id").From("table1").OrderBy("id").Limit(10)
+	q2 := Selects("id").From("table2")
+
+	union := Union(q1, q2)
+	if s := union.SetDialect(MySQL).String(); s != "(SELECT `id` FROM `table1` ORDER BY `id` LIMIT 10) UNION SELECT `id` FROM `table2`" {
+		t.Errorf("unexpected sql '%s'", s)
+	}
+	expectUnsupported(t, "compound member limit", union.SetDialect(Sqlite3).String)
+
+	except := Except(q2, Selects("id").From("table3"))
+	if s := except.SetDialect(MySQL).String(); s != "SELECT `id` FROM `table2` EXCEPT SELECT `id` FROM `table3`" {
+		t.Errorf("unexpected sql '%s'", s)
+	}
+	expectUnsupported(t, "EXCEPT", except.SetDialect(mysql57Dialect{MySQL}).String)
+}
+
+func TestCapabilitiesWindow(t *testing.T) {
+	sel := Select("id").SelectOver(Sum("amount"), NewWindow("w"), "total").From("orders").
+		Window("w", NewWindow().PartitionBy("user_id"))
+	if s := sel.SetDialect(MySQL).String(); s != "SELECT `id`, SUM(`amount`) OVER `w` AS `total` FROM `orders` WINDOW `w` AS (PARTITION BY `user_id`)" {
+		t.Errorf("unexpected sql '%s'", s)
+	}
+	expectUnsupported(t, "WINDOW", sel.SetDialect(MSSQL).String)
+	expectUnsupported(t, "WINDOW", sel.SetDialect(Oracle).String)
+
+	sel = Select("id").SelectOver(Sum("amount"), NewWindow().PartitionBy("user_id"), "total").From("orders")
+	if s := sel.SetDialect(MSSQL).String(); s != "SELECT [id], SUM([amount]) OVER (PARTITION BY [user_id]) AS [total] FROM [orders]" {
+		t.Errorf("unexpected sql '%s'", s)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+type caseWhen struct {
+	When Condition
+	Then interface{}
+}
+
+// CaseBuilder is used to build the searched CASE expression,
+// which has implemented the interface Expression.
+type CaseBuilder struct {
+	whens   []caseWhen
+	elseVal interface{}
+	hasElse bool
+
+	// err is the error occurred when adding the branches,
+	// which is reported when building.
+	err error
+}
+
+// Case returns a new CASE expression builder, which is built as
+// "CASE WHEN cond THEN value ... [ELSE value] END". For example,
+//
+//	Case().When(Equal("status", 1), "active").When(IsNull("status"), "unknown").Else("inactive")
+//	// CASE WHEN `status`=? THEN ? WHEN `status` IS NULL THEN ? ELSE ? END
+//
+//	Selects("user_id").SelectExpr(Expr("SUM(?)", Case().When(Equal("status", 1), Raw("1")).Else(Raw("0"))), "paid")
+//	// SELECT `user_id`, SUM(CASE WHEN `status`=? THEN 1 ELSE 0 END) AS `paid`
+//
+// The THEN and ELSE values are bound as the arguments. But if the value is
+// an Expression, such as Ident("column") and Raw("1"), it is built and inlined
+// instead. Notice: the untyped arguments are resolved as text by PostgreSQL,
+// so use the literals by Raw, or cast them like Expr("CAST(? AS INTEGER)", 1),
+// for the numeric values used by the arithmetic or the aggregate functions.
+func Case() *CaseBuilder { return &CaseBuilder{} }
+
+// When appends the branch "WHEN cond THEN then".
+func (c *CaseBuilder) When(cond Condition, then interface{}) *CaseBuilder {
+	if cond == nil {
+		if c.err == nil {
+			c.err = buildErrorf("CaseBuilder", ErrInvalidStatement,
+				"the WHEN condition must not be nil")
+		}
+		return c
+	}
+	c.whens = append(c.whens, caseWhen{When: cond, Then: then})
+	return c
+}
+
+// Else sets the value of ELSE.
+func (c *CaseBuilder) Else(value interface{}) *CaseBuilder {
+	c.elseVal = value
+	c.hasElse = true
+	return c
+}
+
+// Clone returns a copy of the CASE expression builder.
+func (c *CaseBuilder) Clone() *CaseBuilder {
+	_c := *c
+	_c.whens = append([]caseWhen(nil), c.whens...)
+	return &_c
+}
+
+// Build implements the interface Expression.
+func (c *CaseBuilder) Build(ab *ArgsBuilder) string {
+	if c.err != nil {
+		panic(c.err)
+	} else if len(c.whens) == 0 {
+		panic(buildErrorf("CaseBuilder", ErrInvalidStatement, "no WHEN branches"))
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	buf.WriteString("CASE")
+	for _, w := range c.whens {
+		buf.WriteString(" WHEN ")
+		buf.WriteString(w.When.Build(ab))
+		buf.WriteString(" THEN ")
+		buf.WriteString(buildValue(ab, w.Then))
+	}
+
+	if c.hasElse {
+		buf.WriteString(" ELSE ")
+		buf.WriteString(buildValue(ab, c.elseVal))
+	}
+
+	buf.WriteString(" END")
+	return buf.String()
+}
+
+// buildValue builds the expression if value is an Expression,
+// or adds it as the argument and returns its placeholder.
+func buildValue(ab *ArgsBuilder, value interface{}) string {
+	if expr, ok := value.(Expression); ok {
+		return expr.Build(ab)
+	}
+	return ab.Add(value)
+}
@@ -0,0 +1,44 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import "fmt"
+
+func ExampleCase() {
+	paid := Case().When(Equal("status", 1), Raw("1")).Else(Raw("0"))
+	priority := Case().When(Equal("level", "vip"), 0).When(IsNull("level"), 2).Else(1)
+
+	s := Selects("user_id").SelectExpr(Expr("SUM(?)", paid), "paid").From("orders").
+		Where(Greater("amount", 100)).GroupBy("user_id").OrderByExpr(priority)
+
+	update := Update().Table("users").Set(
+		AssignExpr("level", Case().When(Greater("score", 1000), "vip").Else(Ident("level"))),
+	).Where(Equal("status", 1))
+
+	sql1, args1 := s.SetDialect(Postgres).Build()
+	sql2, args2 := update.SetDialect(MySQL).Build()
+
+	fmt.Println(sql1)
+	fmt.Println(args1)
+
+	fmt.Println(sql2)
+	fmt.Println(args2)
+
+	// Output:
+	// SELECT "user_id", SUM(CASE WHEN "status"=$1 THEN 1 ELSE 0 END) AS "paid" FROM "orders" WHERE "amount">$2 GROUP BY "user_id" ORDER BY CASE WHEN "level"=$3 THEN $4 WHEN "level" IS NULL THEN $5 ELSE $6 END
+	// [1 100 vip 0 2 1]
+	// UPDATE `users` SET `level`=CASE WHEN `score`>? THEN ? ELSE `level` END WHERE `status`=?
+	// [1000 vip 1]
+}
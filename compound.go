@@ -0,0 +1,358 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import "context"
+
+// Predefine some compound operators.
+const (
+	unionOp     = "UNION"
+	unionAllOp  = "UNION ALL"
+	intersectOp = "INTERSECT"
+	exceptOp    = "EXCEPT"
+)
+
+// Union is short for NewCompoundBuilder(queries[0]).Union(queries[1:]...).
+func Union(queries ...*SelectBuilder) *CompoundBuilder {
+	return newCompoundBuilder(unionOp, queries)
+}
+
+// UnionAll is short for NewCompoundBuilder(queries[0]).UnionAll(queries[1:]...).
+func UnionAll(queries ...*SelectBuilder) *CompoundBuilder {
+	return newCompoundBuilder(unionAllOp, queries)
+}
+
+// Intersect is short for NewCompoundBuilder(queries[0]).Intersect(queries[1:]...).
+func Intersect(queries ...*SelectBuilder) *CompoundBuilder {
+	return newCompoundBuilder(intersectOp, queries)
+}
+
+// Except is short for NewCompoundBuilder(queries[0]).Except(queries[1:]...).
+func Except(queries ...*SelectBuilder) *CompoundBuilder {
+	return newCompoundBuilder(exceptOp, queries)
+}
+
+func newCompoundBuilder(op string, queries []*SelectBuilder) *CompoundBuilder {
+	if len(queries) == 0 {
+		b := &CompoundBuilder{dialect: DefaultDialect}
+		return b.setError(buildErrorf("CompoundBuilder", ErrInvalidStatement,
+			"no SELECT queries"))
+	}
+	return NewCompoundBuilder(queries[0]).add(op, queries[1:])
+}
+
+// NewCompoundBuilder returns a new compound SELECT builder starting with
+// the query, which inherits the dialect and executor of query.
+func NewCompoundBuilder(query *SelectBuilder) *CompoundBuilder {
+	if query == nil {
+		b := &CompoundBuilder{dialect: DefaultDialect}
+		return b.setError(errNilCompoundQuery)
+	}
+
+	dialect := query.dialect
+	if dialect == nil {
+		dialect = DefaultDialect
+	}
+
+	return &CompoundBuilder{
+		dialect:  dialect,
+		executor: query.executor,
+		queries:  []compoundQuery{{Query: query}},
+	}
+}
+
+type compoundQuery struct {
+	Op    string
+	Query *SelectBuilder
+}
+
+// CompoundBuilder is used to build the compound SELECT statement, such as
+// "SELECT ... UNION SELECT ... ORDER BY ... LIMIT ...".
+//
+// All the SELECT queries are built with the dialect of CompoundBuilder,
+// and their arguments are numbered consecutively, but their interceptors
+// are ignored.
+type CompoundBuilder struct {
+	intercept Interceptor
+	executor  Executor
+	dialect   Dialect
+
+	queries  []compoundQuery
+	orderbys []orderby
+	limit    int64
+	offset   int64
+
+	// err is the error occurred when chaining the builder,
+	// which is reported when building.
+	err error
+
+	immutable bool
+}
+
+var errNilCompoundQuery = buildErrorf("CompoundBuilder", ErrInvalidStatement,
+	"the SELECT query must not be nil")
+
+// setError records the first error occurred when chaining the builder.
+func (b *CompoundBuilder) setError(err error) *CompoundBuilder {
+	if b.err == nil {
+		b.err = err
+	}
+	return b
+}
+
+func (b *CompoundBuilder) add(op string, queries []*SelectBuilder) *CompoundBuilder {
+	b = b.writable()
+	for _, query := range queries {
+		if query == nil {
+			return b.setError(errNilCompoundQuery)
+		}
+		b.queries = append(b.queries, compoundQuery{Op: op, Query: query})
+	}
+	return b
+}
+
+// Union appends the queries with "UNION", which removes the duplicate rows.
+func (b *CompoundBuilder) Union(queries ...*SelectBuilder) *CompoundBuilder {
+	return b.add(unionOp, queries)
+}
+
+// UnionAll appends the queries with "UNION ALL".
+func (b *CompoundBuilder) UnionAll(queries ...*SelectBuilder) *CompoundBuilder {
+	return b.add(unionAllOp, queries)
+}
+
+// Intersect appends the queries with "INTERSECT".
+func (b *CompoundBuilder) Intersect(queries ...*SelectBuilder) *CompoundBuilder {
+	return b.add(intersectOp, queries)
+}
+
+// Except appends the queries with "EXCEPT", which is built as "MINUS"
+// for Oracle.
+func (b *CompoundBuilder) Except(queries ...*SelectBuilder) *CompoundBuilder {
+	return b.add(exceptOp, queries)
+}
+
+// OrderBy appends the column used by the outer ORDER BY, which should be
+// the name or alias of the selected column.
+func (b *CompoundBuilder) OrderBy(column string, order ...Order) *CompoundBuilder {
+	b = b.writable()
+	ob := orderby{Column: column}
+	if len(order) > 0 {
+		ob.Order = order[0]
+	}
+	b.orderbys = append(b.orderbys, ob)
+	return b
+}
+
+// OrderByDesc appends the column used by the outer ORDER BY DESC.
+func (b *CompoundBuilder) OrderByDesc(column string) *CompoundBuilder {
+	return b.OrderBy(column, Desc)
+}
+
+// OrderByAsc appends the column used by the outer ORDER BY ASC.
+func (b *CompoundBuilder) OrderByAsc(column string) *CompoundBuilder {
+	return b.OrderBy(column, Asc)
+}
+
+// Limit sets the LIMIT of the compound query.
+func (b *CompoundBuilder) Limit(limit int64) *CompoundBuilder {
+	b = b.writable()
+	b.limit = limit
+	return b
+}
+
+// Offset sets the OFFSET of the compound query.
+func (b *CompoundBuilder) Offset(offset int64) *CompoundBuilder {
+	b = b.writable()
+	b.offset = offset
+	return b
+}
+
+// Paginate is the same as b.Limit(pageSize).Offset(pageNum * pageSize).
+func (b *CompoundBuilder) Paginate(pageNum, pageSize int64) *CompoundBuilder {
+	return b.Limit(pageSize).Offset(pageNum * pageSize)
+}
+
+// Query builds the sql and executes it by *sql.DB.
+func (b *CompoundBuilder) Query() (Rows, error) {
+	return b.QueryContext(context.Background())
+}
+
+// QueryContext builds the sql and executes it by *sql.DB.
+//
+// The selected columns of the returned Rows are those of the first query.
+func (b *CompoundBuilder) QueryContext(ctx context.Context) (Rows, error) {
+	query, args, err := b.BuildE()
+	if err != nil {
+		return Rows{SelectBuilder: b.first()}, err
+	}
+
+	rows, err := b.executor.QueryContext(ctx, query, args...)
+	return Rows{SelectBuilder: b.first(), Rows: rows}, err
+}
+
+// QueryRow builds the sql and executes it by *sql.DB.
+func (b *CompoundBuilder) QueryRow() Row {
+	return b.QueryRowContext(context.Background())
+}
+
+// QueryRowContext builds the sql and executes it by *sql.DB.
+//
+// The selected columns of the returned Row are those of the first query.
+func (b *CompoundBuilder) QueryRowContext(ctx context.Context) Row {
+	query, args, err := b.BuildE()
+	if err != nil {
+		return Row{SelectBuilder: b.first(), err: err}
+	}
+	return Row{SelectBuilder: b.first(),
+		Row: b.executor.QueryRowContext(ctx, query, args...)}
+}
+
+// first returns the first query, or nil if there is no query,
+// which has been reported as the build error.
+func (b *CompoundBuilder) first() *SelectBuilder {
+	if len(b.queries) == 0 {
+		return nil
+	}
+	return b.queries[0].Query
+}
+
+// SetExecutor sets the executor to exec.
+func (b *CompoundBuilder) SetExecutor(exec Executor) *CompoundBuilder {
+	b = b.writable()
+	b.executor = exec
+	return b
+}
+
+// SetInterceptor sets the interceptor to f.
+func (b *CompoundBuilder) SetInterceptor(f Interceptor) *CompoundBuilder {
+	b = b.writable()
+	b.intercept = f
+	return b
+}
+
+// SetDialect resets the dialect.
+func (b *CompoundBuilder) SetDialect(dialect Dialect) *CompoundBuilder {
+	b = b.writable()
+	b.dialect = dialect
+	return b
+}
+
+// Clone returns a deep copy of the builder, which can be modified without
+// affecting the original, and the SELECT queries are also cloned.
+// The returned builder is always mutable even if b is immutable.
+func (b *CompoundBuilder) Clone() *CompoundBuilder {
+	c := *b
+	c.immutable = false
+	c.orderbys = append([]orderby(nil), b.orderbys...)
+	c.queries = make([]compoundQuery, len(b.queries))
+	for i, q := range b.queries {
+		if !q.Query.immutable {
+			q.Query = q.Query.Clone()
+		}
+		c.queries[i] = q
+	}
+	return &c
+}
+
+// Immutable returns an immutable clone of the builder, which is copied
+// on write like SelectBuilder.Immutable.
+func (b *CompoundBuilder) Immutable() *CompoundBuilder {
+	c := b.Clone()
+	c.immutable = true
+	return c
+}
+
+func (b *CompoundBuilder) writable() *CompoundBuilder {
+	if b.immutable {
+		return b.Immutable()
+	}
+	return b
+}
+
+// BuildE is the same as Build, but returns the error instead of panicking,
+// which is BuildError or UnsupportedError.
+func (b *CompoundBuilder) BuildE() (sql string, args []interface{}, err error) {
+	defer recoverBuildError(&err)
+	sql, args = b.Build()
+	return
+}
+
+// String is the same as b.Build(), except args.
+func (b *CompoundBuilder) String() string {
+	sql, _ := b.Build()
+	return sql
+}
+
+// Build builds the compound SELECT sql statement.
+func (b *CompoundBuilder) Build() (sql string, args []interface{}) {
+	dialect := b.dialect
+	if dialect == nil {
+		dialect = DefaultDialect
+	}
+
+	ab := NewArgsBuilder(dialect)
+	sql = b.build(ab)
+	return intercept(b.intercept, sql, ab.Args())
+}
+
+// BuildNested implements the interface NestedBuilder, which builds
+// the compound SELECT sql statement with the dialect of ab.
+func (b *CompoundBuilder) BuildNested(ab *ArgsBuilder) string {
+	return b.build(ab)
+}
+
+func (b *CompoundBuilder) build(ab *ArgsBuilder) (sql string) {
+	if b.err != nil {
+		panic(b.err)
+	}
+
+	caps := GetCapabilities(ab.Dialect)
+	buf := getBuffer()
+	for _, q := range b.queries {
+		if q.Op != "" {
+			if (q.Op == intersectOp || q.Op == exceptOp) && !caps.IntersectExcept {
+				panic(unsupported(ab.Dialect, q.Op))
+			}
+
+			buf.WriteByte(' ')
+			if q.Op == exceptOp && caps.ExceptMinus {
+				buf.WriteString("MINUS")
+			} else {
+				buf.WriteString(q.Op)
+			}
+			buf.WriteByte(' ')
+		}
+
+		// The query with ORDER BY or LIMIT must be enclosed in parentheses,
+		// which is not supported by SQLite.
+		query := q.Query
+		if len(query.orderbys) > 0 || query.limit > 0 || query.offset > 0 {
+			if !caps.CompoundMemberLimit {
+				panic(unsupported(ab.Dialect, "ORDER BY/LIMIT in compound member"))
+			}
+			buf.WriteByte('(')
+			buf.WriteString(query.BuildNested(ab))
+			buf.WriteByte(')')
+		} else {
+			buf.WriteString(query.BuildNested(ab))
+		}
+	}
+
+	buildOrderByLimit(buf, ab, b.orderbys, b.limit, b.offset)
+	sql = buf.String()
+	putBuffer(buf)
+	return
+}
@@ -0,0 +1,39 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import "fmt"
+
+func ExampleCompoundBuilder() {
+	q1 := Selects("id", "name").From("users").Where(Equal("status", 1))
+	q2 := Selects("id", "name").From("admins").Where(Equal("status", 2))
+	q3 := Selects("id", "name").From("guests").Where(Equal("status", 3))
+
+	union := UnionAll(q1, q2).Except(q3).OrderBy("name").Limit(10)
+	sql1, args1 := union.SetDialect(Postgres).Build()
+	sql2, args2 := union.SetDialect(Oracle).Build()
+
+	fmt.Println(sql1)
+	fmt.Println(args1)
+
+	fmt.Println(sql2)
+	fmt.Println(args2)
+
+	// Output:
+	// SELECT "id", "name" FROM "users" WHERE "status"=$1 UNION ALL SELECT "id", "name" FROM "admins" WHERE "status"=$2 EXCEPT SELECT "id", "name" FROM "guests" WHERE "status"=$3 ORDER BY "name" LIMIT 10
+	// [1 2 3]
+	// SELECT "id", "name" FROM "users" WHERE "status"=:1 UNION ALL SELECT "id", "name" FROM "admins" WHERE "status"=:2 MINUS SELECT "id", "name" FROM "guests" WHERE "status"=:3 ORDER BY "name" FETCH FIRST 10 ROWS ONLY
+	// [1 2 3]
+}
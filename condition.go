@@ -128,6 +128,25 @@ func IsNotNull(column string) Condition {
 
 /// --------------------------------------------------------------------------
 
+type boolCondition struct {
+	column string
+	value  bool
+}
+
+func (c boolCondition) Build(b *ArgsBuilder) string {
+	return fmt.Sprintf("%s=%s", b.Quote(c.column), GetCapabilities(b.Dialect).Bool(c.value))
+}
+
+// IsTrue returns a "column=TRUE" expression, which uses "column=1" instead
+// if the dialect does not support the boolean literals.
+func IsTrue(column string) Condition { return boolCondition{column, true} }
+
+// IsFalse returns a "column=FALSE" expression, which uses "column=0" instead
+// if the dialect does not support the boolean literals.
+func IsFalse(column string) Condition { return boolCondition{column, false} }
+
+/// --------------------------------------------------------------------------
+
 type inCondition struct {
 	format string
 	column string
@@ -177,6 +196,109 @@ func NotBetween(column string, lower, upper interface{}) Condition {
 
 /// --------------------------------------------------------------------------
 
+type selectCondition struct {
+	format string
+	column string
+	query  Builder
+}
+
+func (c selectCondition) Build(b *ArgsBuilder) string {
+	if c.column == "" {
+		return fmt.Sprintf(c.format, buildNested(b, c.query))
+	}
+	return fmt.Sprintf(c.format, b.Quote(c.column), buildNested(b, c.query))
+}
+
+// InSelect returns a "column IN (query)" expression.
+//
+// If query implements the interface NestedBuilder, such as SelectBuilder,
+// it is built with the dialect of the parent statement and its arguments
+// are numbered after the former arguments. It's the same for NotInSelect,
+// Exists, NotExists and CompareSelect.
+func InSelect(column string, query Builder) Condition {
+	return selectCondition{"%s IN (%s)", column, query}
+}
+
+// NotInSelect returns a "column NOT IN (query)" expression.
+func NotInSelect(column string, query Builder) Condition {
+	return selectCondition{"%s NOT IN (%s)", column, query}
+}
+
+// Exists returns a "EXISTS (query)" expression.
+func Exists(query Builder) Condition {
+	return selectCondition{"EXISTS (%s)", "", query}
+}
+
+// NotExists returns a "NOT EXISTS (query)" expression.
+func NotExists(query Builder) Condition {
+	return selectCondition{"NOT EXISTS (%s)", "", query}
+}
+
+// CompareSelect returns a Condition to compare the column with the scalar
+// subquery, which must return a single row with a single column.
+//
+// op must be one of "=", "<>", "!=", "<", "<=", ">" and ">=", which may be
+// followed by ANY or ALL to compare with all the rows of the subquery.
+// Or, the error is reported when building.
+//
+// For example,
+//
+//	CompareSelect("price", ">", Selects().SelectExpr(Avg("price")).From("goods")) ==> "price>(SELECT AVG(price) FROM goods)"
+//	CompareSelect("price", ">= ALL", Select("price").From("goods")) ==> "price>=ALL (SELECT price FROM goods)"
+func CompareSelect(column, op string, query Builder) Condition {
+	fields := strings.Fields(strings.ToUpper(op))
+	if len(fields) == 0 || len(fields) > 2 || !compareOps[fields[0]] ||
+		(len(fields) == 2 && fields[1] != "ANY" && fields[1] != "ALL") {
+		return errorCondition{buildErrorf("Condition", ErrInvalidStatement,
+			"invalid comparison operator '%s'", op)}
+	}
+
+	if len(fields) == 2 {
+		return selectCondition{"%s" + fields[0] + fields[1] + " (%s)", column, query}
+	}
+	return selectCondition{"%s" + fields[0] + "(%s)", column, query}
+}
+
+type exprCondition struct {
+	expr  Expression
+	op    string
+	value interface{}
+}
+
+func (c exprCondition) Build(b *ArgsBuilder) string {
+	return c.expr.Build(b) + c.op + buildValue(b, c.value)
+}
+
+// CompareExpr returns a Condition to compare the expression with the value,
+// such as the aggregate function in HAVING. If value is an Expression,
+// it is built and inlined, or it is added as the argument.
+//
+// op must be one of "=", "<>", "!=", "<", "<=", ">" and ">=".
+// Or, the error is reported when building.
+//
+// For example,
+//
+//	CompareExpr(Count("*"), ">", 10)          ==> "COUNT(*)>?"
+//	CompareExpr(Sum("amount"), ">=", Max("a")) ==> "SUM(amount)>=MAX(a)"
+func CompareExpr(expr Expression, op string, value interface{}) Condition {
+	if op = strings.TrimSpace(op); !compareOps[op] {
+		return errorCondition{buildErrorf("Condition", ErrInvalidStatement,
+			"invalid comparison operator '%s'", op)}
+	}
+	return exprCondition{expr: expr, op: op, value: value}
+}
+
+var compareOps = map[string]bool{
+	"=": true, "<>": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true,
+}
+
+// errorCondition is the invalid condition, which reports the error when building.
+type errorCondition struct{ err error }
+
+func (c errorCondition) Build(*ArgsBuilder) string { panic(c.err) }
+
+/// --------------------------------------------------------------------------
+
 type groupCondition struct {
 	join  string
 	exprs []Condition
@@ -324,6 +446,16 @@ func (c ConditionSet) IsNotNull(column string) Condition {
 	return IsNotNull(column)
 }
 
+// IsTrue is a proxy of IsTrue.
+func (c ConditionSet) IsTrue(column string) Condition {
+	return IsTrue(column)
+}
+
+// IsFalse is a proxy of IsFalse.
+func (c ConditionSet) IsFalse(column string) Condition {
+	return IsFalse(column)
+}
+
 // In is a proxy of In.
 func (c ConditionSet) In(column string, values ...interface{}) Condition {
 	return In(column, values...)
@@ -344,6 +476,27 @@ func (c ConditionSet) NotBetween(column string, lower, upper interface{}) Condit
 	return NotBetween(column, lower, upper)
 }
 
+// InSelect is a proxy of InSelect.
+func (c ConditionSet) InSelect(column string, query Builder) Condition {
+	return InSelect(column, query)
+}
+
+// NotInSelect is a proxy of NotInSelect.
+func (c ConditionSet) NotInSelect(column string, query Builder) Condition {
+	return NotInSelect(column, query)
+}
+
+// Exists is a proxy of Exists.
+func (c ConditionSet) Exists(query Builder) Condition { return Exists(query) }
+
+// NotExists is a proxy of NotExists.
+func (c ConditionSet) NotExists(query Builder) Condition { return NotExists(query) }
+
+// CompareSelect is a proxy of CompareSelect.
+func (c ConditionSet) CompareSelect(column, op string, query Builder) Condition {
+	return CompareSelect(column, op, query)
+}
+
 // And is a proxy of And.
 func (c ConditionSet) And(exprs ...Condition) Condition { return And(exprs...) }
 
@@ -54,3 +54,66 @@ func TestAndOr(t *testing.T) {
 		}
 	}
 }
+
+func TestSelectConditions(t *testing.T) {
+	orders := Select("user_id").From("orders").Where(Greater("amount", 100))
+	vips := Select("id").From("vips").Where(ColumnEqual("vips.id", "users.id"), Equal("level", 3))
+	avg := Selects().SelectExpr(Avg("age")).From("users").Where(Equal("status", 1))
+
+	s := Selects("id").From("users").Where(
+		Equal("status", 1),
+		InSelect("id", orders),
+		Or(Exists(vips), NotInSelect("id", Select("user_id").From("bans"))),
+		NotExists(Select("1").From("locks").Where(ColumnEqual("locks.user_id", "users.id"))),
+		CompareSelect("age", ">", avg),
+	).SetDialect(Postgres)
+
+	expected := `SELECT "id" FROM "users" WHERE ("status"=$1 AND "id" IN (SELECT "user_id" FROM "orders" WHERE "amount">$2) AND (EXISTS (SELECT "id" FROM "vips" WHERE ("vips"."id"="users"."id" AND "level"=$3)) OR "id" NOT IN (SELECT "user_id" FROM "bans")) AND NOT EXISTS (SELECT 1 FROM "locks" WHERE "locks"."user_id"="users"."id") AND "age">(SELECT AVG("age") FROM "users" WHERE "status"=$4))`
+	sql, args := s.Build()
+	if sql != expected {
+		t.Errorf("expected '%s', got '%s'", expected, sql)
+	}
+
+	expecteds := []interface{}{1, 100, 3, 1}
+	if len(args) != len(expecteds) {
+		t.Errorf("expected '%v', got '%v'", expecteds, args)
+	} else {
+		for i, arg := range args {
+			if arg != expecteds[i] {
+				t.Errorf("Index %d: expected '%v', got '%v'", i, expecteds[i], arg)
+			}
+		}
+	}
+
+	prices := Select("price").From("goods")
+	s = Selects("id").From("goods").Where(CompareSelect("price", ">= all", prices))
+	if sql := s.String(); sql != "SELECT `id` FROM `goods` WHERE `price`>=ALL (SELECT `price` FROM `goods`)" {
+		t.Errorf("unexpected sql '%s'", sql)
+	}
+
+	for _, op := range []string{"", "%d", "> ANY ALL", "LIKE", "=1 OR 1="} {
+		_, _, err := Selects("id").From("goods").Where(CompareSelect("price", op, prices)).BuildE()
+		if be, ok := err.(BuildError); !ok || be.Err != ErrInvalidStatement {
+			t.Errorf("%q: expect the error ErrInvalidStatement, but got %v", op, err)
+		}
+	}
+}
+
+func TestCompareExpr(t *testing.T) {
+	s := Selects("area").SelectExpr(CountDistinct("u.id"), "total").From("users", "u").GroupBy("area").
+		HavingCondition(CompareExpr(Sum("amount"), ">=", Max("quota")), CompareExpr(Min("age"), "<>", 18))
+	expected := "SELECT `area`, COUNT(DISTINCT `u`.`id`) AS `total` FROM `users` AS `u` GROUP BY `area` HAVING SUM(`amount`)>=MAX(`quota`) AND MIN(`age`)<>?"
+	if sql, args := s.Build(); sql != expected {
+		t.Errorf("expected '%s', got '%s'", expected, sql)
+	} else if len(args) != 1 || args[0] != 18 {
+		t.Errorf("unexpected args %v", args)
+	}
+
+	for _, op := range []string{"", "> ANY", "LIKE", "=1 OR 1="} {
+		_, _, err := Selects("area").From("users").GroupBy("area").
+			HavingCondition(CompareExpr(Count("*"), op, 10)).BuildE()
+		if be, ok := err.(BuildError); !ok || be.Err != ErrInvalidStatement {
+			t.Errorf("%q: expect the error ErrInvalidStatement, but got %v", op, err)
+		}
+	}
+}
@@ -34,6 +34,7 @@ type columnDefinition struct {
 	Name string
 	Type string
 	Opts []interface{}
+	Raw  string
 }
 
 // TableBuilder is used to build the CREATE TABLE statement.
@@ -47,28 +48,51 @@ type TableBuilder struct {
 
 	temp bool
 	ifne bool
+
+	immutable bool
 }
 
 // Temporary creates the Temporary table, that's, CREATE TEMPORARY TABLE.
 func (b *TableBuilder) Temporary() *TableBuilder {
+	b = b.writable()
 	b.temp = true
 	return b
 }
 
 // IfNotExist adds the setting "IF NOT EXISTS".
 func (b *TableBuilder) IfNotExist() *TableBuilder {
+	b = b.writable()
 	b.ifne = true
 	return b
 }
 
-// Define adds definition of a column or index in CREATE TABLE.
+// Define adds definition of a column in CREATE TABLE,
+// and colName is always quoted by the dialect.
+//
+// For the definition of the index, the constraint or the computed column,
+// use DefineRaw instead.
 func (b *TableBuilder) Define(colName, colType string, colOpts ...interface{}) *TableBuilder {
-	b.defines = append(b.defines, columnDefinition{colName, colType, colOpts})
+	b = b.writable()
+	b.defines = append(b.defines, columnDefinition{Name: colName, Type: colType, Opts: colOpts})
+	return b
+}
+
+// DefineRaw adds the raw definition in CREATE TABLE, which is not quoted,
+// such as the index, the constraint or the computed column. For example,
+//
+//	DefineRaw("PRIMARY KEY (`id`)")
+//	DefineRaw("`total` INT AS (`price` * `qty`)")
+//
+// Notice: define must not contain the input from the untrusted user.
+func (b *TableBuilder) DefineRaw(define string) *TableBuilder {
+	b = b.writable()
+	b.defines = append(b.defines, columnDefinition{Raw: define})
 	return b
 }
 
 // Option adds a table option in CREATE TABLE.
 func (b *TableBuilder) Option(options ...string) *TableBuilder {
+	b = b.writable()
 	b.options = append(b.options, options...)
 	return b
 }
@@ -80,28 +104,68 @@ func (b *TableBuilder) Exec() (sql.Result, error) {
 
 // ExecContext builds the sql and executes it by *sql.DB.
 func (b *TableBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
-	query, args := b.Build()
+	query, args, err := b.BuildE()
+	if err != nil {
+		return nil, err
+	}
 	return b.executor.ExecContext(ctx, query, args...)
 }
 
 // SetExecutor sets the executor to exec.
 func (b *TableBuilder) SetExecutor(exec Executor) *TableBuilder {
+	b = b.writable()
 	b.executor = exec
 	return b
 }
 
 // SetInterceptor sets the interceptor to f.
 func (b *TableBuilder) SetInterceptor(f Interceptor) *TableBuilder {
+	b = b.writable()
 	b.intercept = f
 	return b
 }
 
 // SetDialect resets the dialect.
 func (b *TableBuilder) SetDialect(dialect Dialect) *TableBuilder {
+	b = b.writable()
 	b.dialect = dialect
 	return b
 }
 
+// Clone returns a deep copy of the builder, which can be modified without
+// affecting the original. The returned builder is always mutable even if b
+// is immutable.
+func (b *TableBuilder) Clone() *TableBuilder {
+	c := *b
+	c.immutable = false
+	c.defines = append([]columnDefinition(nil), b.defines...)
+	c.options = append([]string(nil), b.options...)
+	return &c
+}
+
+// Immutable returns an immutable clone of the builder, which is copied
+// on write like SelectBuilder.Immutable.
+func (b *TableBuilder) Immutable() *TableBuilder {
+	c := b.Clone()
+	c.immutable = true
+	return c
+}
+
+func (b *TableBuilder) writable() *TableBuilder {
+	if b.immutable {
+		return b.Immutable()
+	}
+	return b
+}
+
+// BuildE is the same as Build, but returns the error instead of panicking,
+// which is BuildError or UnsupportedError.
+func (b *TableBuilder) BuildE() (sql string, args []interface{}, err error) {
+	defer recoverBuildError(&err)
+	sql, args = b.Build()
+	return
+}
+
 // String is the same as b.Build(), except args.
 func (b *TableBuilder) String() string {
 	sql, _ := b.Build()
@@ -111,9 +175,9 @@ func (b *TableBuilder) String() string {
 // Build builds the CREATE TABLE sql statement.
 func (b *TableBuilder) Build() (sql string, args []interface{}) {
 	if b.table == "" {
-		panic("TableBuilder: no table name")
+		panic(buildError("TableBuilder", ErrNoTable))
 	} else if len(b.defines) == 0 {
-		panic("TableBuilder: no column definition")
+		panic(buildErrorf("TableBuilder", ErrNoColumns, "no column definition"))
 	}
 
 	buf := getBuffer()
@@ -141,6 +205,11 @@ func (b *TableBuilder) Build() (sql string, args []interface{}) {
 		} else {
 			buf.WriteString(",\n    ")
 		}
+		if define.Raw != "" {
+			buf.WriteString(define.Raw)
+			continue
+		}
+
 		buf.WriteString(dialect.Quote(define.Name))
 		buf.WriteByte(' ')
 		buf.WriteString(define.Type)
@@ -23,6 +23,8 @@ func ExampleTableBuilder() {
 		Define("id", "BIGINT", "PRIMARY KEY", "AUTO_INCREMENT").
 		Define("name", "VARCHAR(255)", "NOT NULL", `COMMENT "user name"`).
 		Define("age", "INTEGER", "NOT NULL", "DEFAULT", 123).
+		Define("nick name", "VARCHAR(64)").
+		DefineRaw("INDEX `idx_name` (`name`)").
 		Option("ENGINE=InnoDB", "DEFAULT CHARSET=utf8mb4")
 
 	fmt.Println(table.String())
@@ -31,6 +33,8 @@ func ExampleTableBuilder() {
 	// CREATE TABLE IF NOT EXISTS `table` (
 	//     `id` BIGINT PRIMARY KEY AUTO_INCREMENT,
 	//     `name` VARCHAR(255) NOT NULL COMMENT "user name",
-	//     `age` INTEGER NOT NULL DEFAULT 123
+	//     `age` INTEGER NOT NULL DEFAULT 123,
+	//     `nick name` VARCHAR(64),
+	//     INDEX `idx_name` (`name`)
 	// ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4
 }
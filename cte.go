@@ -0,0 +1,98 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import "bytes"
+
+// commonTable is the common table expression, that's, "name (columns) AS (query)".
+type commonTable struct {
+	Name    string
+	Columns []string
+	Query   Builder
+}
+
+// commonTables is the WITH clause.
+type commonTables struct {
+	Recursive bool
+	Tables    []commonTable
+}
+
+func (t *commonTables) Add(recursive bool, name string, query Builder, columns []string) error {
+	if name == "" {
+		return errEmptyCTEName
+	} else if query == nil {
+		return errNilCTEQuery
+	}
+
+	t.Recursive = t.Recursive || recursive
+	t.Tables = append(t.Tables, commonTable{Name: name, Columns: columns, Query: query})
+	return nil
+}
+
+var (
+	errEmptyCTEName = buildErrorf("SelectBuilder", ErrInvalidStatement,
+		"the name of the common table expression must not be empty")
+	errNilCTEQuery = buildErrorf("SelectBuilder", ErrInvalidStatement,
+		"the query of the common table expression must not be nil")
+)
+
+// Clone returns a deep copy of the WITH clause.
+func (t commonTables) Clone() commonTables {
+	if len(t.Tables) == 0 {
+		return commonTables{Recursive: t.Recursive}
+	}
+
+	tables := make([]commonTable, len(t.Tables))
+	for i, table := range t.Tables {
+		table.Query = cloneBuilder(table.Query)
+		table.Columns = append([]string(nil), table.Columns...)
+		tables[i] = table
+	}
+	return commonTables{Recursive: t.Recursive, Tables: tables}
+}
+
+func (t commonTables) Build(buf *bytes.Buffer, ab *ArgsBuilder) {
+	if len(t.Tables) == 0 {
+		return
+	}
+
+	buf.WriteString("WITH ")
+	if t.Recursive && GetCapabilities(ab.Dialect).RecursiveKeyword {
+		buf.WriteString("RECURSIVE ")
+	}
+
+	for i, table := range t.Tables {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+
+		buf.WriteString(ab.Quote(table.Name))
+		if len(table.Columns) > 0 {
+			buf.WriteString(" (")
+			for j, column := range table.Columns {
+				if j > 0 {
+					buf.WriteString(", ")
+				}
+				buf.WriteString(ab.Quote(column))
+			}
+			buf.WriteByte(')')
+		}
+
+		buf.WriteString(" AS (")
+		buf.WriteString(buildNested(ab, table.Query))
+		buf.WriteByte(')')
+	}
+	buf.WriteByte(' ')
+}
@@ -40,10 +40,15 @@ type DeleteBuilder struct {
 	ftables   []sqlTable
 	joins     []joinTable
 	where     []Condition
+
+	returnings []string
+
+	immutable bool
 }
 
 // Table appends the table name to delete the rows from it.
 func (b *DeleteBuilder) Table(table string) *DeleteBuilder {
+	b = b.writable()
 	if table != "" {
 		b.dtables = append(b.dtables, table)
 	}
@@ -52,6 +57,7 @@ func (b *DeleteBuilder) Table(table string) *DeleteBuilder {
 
 // From sets the table name from where to be deleted.
 func (b *DeleteBuilder) From(table string, alias ...string) *DeleteBuilder {
+	b = b.writable()
 	if table != "" {
 		var talias string
 		if len(alias) != 0 {
@@ -62,55 +68,156 @@ func (b *DeleteBuilder) From(table string, alias ...string) *DeleteBuilder {
 	return b
 }
 
+// Join appends the "JOIN table ON on..." statement, such as On("t1.id", "t2.tid").
+//
+// For the general conditions, such as Equal("t2.type", 1), use JoinCond instead.
+func (b *DeleteBuilder) Join(table, alias string, ons ...JoinOn) *DeleteBuilder {
+	return b.joinTable("", table, alias, joinOns(ons))
+}
+
+// JoinCond appends the "JOIN table ON cond..." statement, which conds may be
+// any Condition, such as On("t1.id", "t2.tid") and Equal("t2.type", 1),
+// and are joined by AND.
+func (b *DeleteBuilder) JoinCond(table, alias string, conds ...Condition) *DeleteBuilder {
+	return b.joinTable("", table, alias, conds)
+}
+
 // JoinLeft appends the "LEFT JOIN table ON on..." statement.
 func (b *DeleteBuilder) JoinLeft(table, alias string, ons ...JoinOn) *DeleteBuilder {
-	return b.joinTable("LEFT", table, alias, ons...)
+	return b.joinTable("LEFT", table, alias, joinOns(ons))
+}
+
+// JoinLeftCond appends the "LEFT JOIN table ON cond..." statement like JoinCond.
+func (b *DeleteBuilder) JoinLeftCond(table, alias string, conds ...Condition) *DeleteBuilder {
+	return b.joinTable("LEFT", table, alias, conds)
 }
 
 // JoinLeftOuter appends the "LEFT OUTER JOIN table ON on..." statement.
 func (b *DeleteBuilder) JoinLeftOuter(table, alias string, ons ...JoinOn) *DeleteBuilder {
-	return b.joinTable("LEFT OUTER", table, alias, ons...)
+	return b.joinTable("LEFT OUTER", table, alias, joinOns(ons))
+}
+
+// JoinLeftOuterCond appends the "LEFT OUTER JOIN table ON cond..." statement like JoinCond.
+func (b *DeleteBuilder) JoinLeftOuterCond(table, alias string, conds ...Condition) *DeleteBuilder {
+	return b.joinTable("LEFT OUTER", table, alias, conds)
 }
 
 // JoinRight appends the "RIGHT JOIN table ON on..." statement.
 func (b *DeleteBuilder) JoinRight(table, alias string, ons ...JoinOn) *DeleteBuilder {
-	return b.joinTable("RIGHT", table, alias, ons...)
+	return b.joinTable("RIGHT", table, alias, joinOns(ons))
+}
+
+// JoinRightCond appends the "RIGHT JOIN table ON cond..." statement like JoinCond.
+func (b *DeleteBuilder) JoinRightCond(table, alias string, conds ...Condition) *DeleteBuilder {
+	return b.joinTable("RIGHT", table, alias, conds)
 }
 
 // JoinRightOuter appends the "RIGHT OUTER JOIN table ON on..." statement.
 func (b *DeleteBuilder) JoinRightOuter(table, alias string, ons ...JoinOn) *DeleteBuilder {
-	return b.joinTable("RIGHT OUTER", table, alias, ons...)
+	return b.joinTable("RIGHT OUTER", table, alias, joinOns(ons))
+}
+
+// JoinRightOuterCond appends the "RIGHT OUTER JOIN table ON cond..." statement like JoinCond.
+func (b *DeleteBuilder) JoinRightOuterCond(table, alias string, conds ...Condition) *DeleteBuilder {
+	return b.joinTable("RIGHT OUTER", table, alias, conds)
 }
 
 // JoinFull appends the "FULL JOIN table ON on..." statement.
 func (b *DeleteBuilder) JoinFull(table, alias string, ons ...JoinOn) *DeleteBuilder {
-	return b.joinTable("FULL", table, alias, ons...)
+	return b.joinTable("FULL", table, alias, joinOns(ons))
+}
+
+// JoinFullCond appends the "FULL JOIN table ON cond..." statement like JoinCond.
+func (b *DeleteBuilder) JoinFullCond(table, alias string, conds ...Condition) *DeleteBuilder {
+	return b.joinTable("FULL", table, alias, conds)
 }
 
 // JoinFullOuter appends the "FULL OUTER JOIN table ON on..." statement.
 func (b *DeleteBuilder) JoinFullOuter(table, alias string, ons ...JoinOn) *DeleteBuilder {
-	return b.joinTable("FULL OUTER", table, alias, ons...)
+	return b.joinTable("FULL OUTER", table, alias, joinOns(ons))
+}
+
+// JoinFullOuterCond appends the "FULL OUTER JOIN table ON cond..." statement like JoinCond.
+func (b *DeleteBuilder) JoinFullOuterCond(table, alias string, conds ...Condition) *DeleteBuilder {
+	return b.joinTable("FULL OUTER", table, alias, conds)
 }
 
-func (b *DeleteBuilder) joinTable(cmd, table, alias string, ons ...JoinOn) *DeleteBuilder {
+// JoinUsing appends the "JOIN table USING (columns...)" statement.
+func (b *DeleteBuilder) JoinUsing(table, alias string, columns ...string) *DeleteBuilder {
+	b = b.writable()
+	b.joins = append(b.joins, joinTable{Table: table, Alias: alias, Using: columns})
+	return b
+}
+
+// JoinCross appends the "CROSS JOIN table" statement.
+func (b *DeleteBuilder) JoinCross(table, alias string) *DeleteBuilder {
+	return b.joinTable("CROSS", table, alias, nil)
+}
+
+// JoinNatural appends the "NATURAL JOIN table" statement.
+func (b *DeleteBuilder) JoinNatural(table, alias string) *DeleteBuilder {
+	return b.joinTable("NATURAL", table, alias, nil)
+}
+
+func (b *DeleteBuilder) joinTable(cmd, table, alias string, ons []Condition) *DeleteBuilder {
+	b = b.writable()
 	b.joins = append(b.joins, joinTable{Type: cmd, Table: table, Alias: alias, Ons: ons})
 	return b
 }
 
 // Where sets the WHERE conditions.
 func (b *DeleteBuilder) Where(andConditions ...Condition) *DeleteBuilder {
+	b = b.writable()
 	b.where = append(b.where, andConditions...)
 	return b
 }
 
 // WhereNamedArgs is the same as Where, but uses the NamedArg as the condition.
 func (b *DeleteBuilder) WhereNamedArgs(args ...NamedArg) *DeleteBuilder {
-	for _, arg := range args {
-		b.Where(b.Equal(arg.Name(), arg.Get()))
+	conds := make([]Condition, len(args))
+	for i, arg := range args {
+		conds[i] = b.Equal(arg.Name(), arg.Get())
 	}
+	return b.Where(conds...)
+}
+
+// Returning sets the columns returned by the DELETE statement,
+// that's, "RETURNING columns..." for PostgreSQL and SQLite,
+// and "OUTPUT DELETED.column..." for SQL Server.
+//
+// Use Query or QueryRow instead of Exec to get the returned rows.
+func (b *DeleteBuilder) Returning(columns ...string) *DeleteBuilder {
+	b = b.writable()
+	b.returnings = columns
 	return b
 }
 
+// Query builds the sql and executes it by *sql.DB, which is used
+// with Returning.
+func (b *DeleteBuilder) Query() (Rows, error) {
+	return b.QueryContext(context.Background())
+}
+
+// QueryContext builds the sql and executes it by *sql.DB, which is used
+// with Returning.
+func (b *DeleteBuilder) QueryContext(ctx context.Context) (Rows, error) {
+	query, args, err := b.BuildE()
+	return returningBuilder{b.executor, b.returnings}.Query(ctx, query, args, err)
+}
+
+// QueryRow builds the sql and executes it by *sql.DB, which is used
+// with Returning.
+func (b *DeleteBuilder) QueryRow() Row {
+	return b.QueryRowContext(context.Background())
+}
+
+// QueryRowContext builds the sql and executes it by *sql.DB, which is used
+// with Returning.
+func (b *DeleteBuilder) QueryRowContext(ctx context.Context) Row {
+	query, args, err := b.BuildE()
+	return returningBuilder{b.executor, b.returnings}.QueryRow(ctx, query, args, err)
+}
+
 // Exec builds the sql and executes it by *sql.DB.
 func (b *DeleteBuilder) Exec() (sql.Result, error) {
 	return b.ExecContext(context.Background())
@@ -118,28 +225,71 @@ func (b *DeleteBuilder) Exec() (sql.Result, error) {
 
 // ExecContext builds the sql and executes it by *sql.DB.
 func (b *DeleteBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
-	query, args := b.Build()
+	query, args, err := b.BuildE()
+	if err != nil {
+		return nil, err
+	}
 	return b.executor.ExecContext(ctx, query, args...)
 }
 
 // SetExecutor sets the executor to exec.
 func (b *DeleteBuilder) SetExecutor(exec Executor) *DeleteBuilder {
+	b = b.writable()
 	b.executor = exec
 	return b
 }
 
 // SetInterceptor sets the interceptor to f.
 func (b *DeleteBuilder) SetInterceptor(f Interceptor) *DeleteBuilder {
+	b = b.writable()
 	b.intercept = f
 	return b
 }
 
 // SetDialect resets the dialect.
 func (b *DeleteBuilder) SetDialect(dialect Dialect) *DeleteBuilder {
+	b = b.writable()
 	b.dialect = dialect
 	return b
 }
 
+// Clone returns a deep copy of the builder, which can be modified without
+// affecting the original, but the conditions are shared.
+// The returned builder is always mutable even if b is immutable.
+func (b *DeleteBuilder) Clone() *DeleteBuilder {
+	c := *b
+	c.immutable = false
+	c.dtables = append([]string(nil), b.dtables...)
+	c.ftables = cloneTables(b.ftables)
+	c.joins = cloneJoins(b.joins)
+	c.where = append([]Condition(nil), b.where...)
+	c.returnings = append([]string(nil), b.returnings...)
+	return &c
+}
+
+// Immutable returns an immutable clone of the builder, which is copied
+// on write like SelectBuilder.Immutable.
+func (b *DeleteBuilder) Immutable() *DeleteBuilder {
+	c := b.Clone()
+	c.immutable = true
+	return c
+}
+
+func (b *DeleteBuilder) writable() *DeleteBuilder {
+	if b.immutable {
+		return b.Immutable()
+	}
+	return b
+}
+
+// BuildE is the same as Build, but returns the error instead of panicking,
+// which is BuildError or UnsupportedError.
+func (b *DeleteBuilder) BuildE() (sql string, args []interface{}, err error) {
+	defer recoverBuildError(&err)
+	sql, args = b.Build()
+	return
+}
+
 // String is the same as b.Build(), except args.
 func (b *DeleteBuilder) String() string {
 	sql, _ := b.Build()
@@ -149,7 +299,7 @@ func (b *DeleteBuilder) String() string {
 // Build builds the DELETE FROM TABLE sql statement.
 func (b *DeleteBuilder) Build() (sql string, args []interface{}) {
 	if len(b.ftables) == 0 {
-		panic("DeleteBuilder: no from table name")
+		panic(buildErrorf("DeleteBuilder", ErrNoTable, "no from table name"))
 	}
 
 	dialect := b.dialect
@@ -157,30 +307,73 @@ func (b *DeleteBuilder) Build() (sql string, args []interface{}) {
 		dialect = DefaultDialect
 	}
 
+	dtables, ftables := b.dtables, b.ftables
+	var using bool
+	switch style := GetCapabilities(dialect).Delete; style {
+	case DeleteGeneric:
+	case DeleteJoin, DeleteFromJoin:
+		if style == DeleteFromJoin && len(dtables) > 1 {
+			panic(unsupported(dialect, "DELETE from multiple tables"))
+		}
+
+		// Such as MySQL and SQL Server, the deleted table must be specified
+		// when there are other tables.
+		if len(dtables) == 0 && (len(ftables) > 1 || len(b.joins) > 0 ||
+			(style == DeleteFromJoin && ftables[0].Alias != "")) {
+			dtables = []string{ftables[0].Alias}
+			if dtables[0] == "" {
+				dtables[0] = ftables[0].Table
+			}
+		}
+	case DeleteUsing:
+		if len(b.joins) > 0 {
+			panic(unsupported(dialect, "JOIN in DELETE"))
+		} else if len(dtables) > 1 || (len(dtables) == 1 &&
+			!ftables[0].IsNamed(dtables[0])) {
+			panic(unsupported(dialect, "DELETE from multiple tables"))
+		}
+		dtables = nil
+		using = true
+	default:
+		if len(b.joins) > 0 || len(ftables) > 1 || len(dtables) > 1 ||
+			(len(dtables) == 1 && !ftables[0].IsNamed(dtables[0])) {
+			panic(unsupported(dialect, "DELETE from multiple tables"))
+		}
+		dtables = nil
+	}
+
+	ab := NewArgsBuilder(dialect)
 	buf := getBuffer()
 	buf.WriteString("DELETE ")
-	for i, table := range b.dtables {
+	for i, table := range dtables {
 		if i > 0 {
 			buf.WriteString(", ")
 		}
 		buf.WriteString(dialect.Quote(table))
 	}
+	if len(dtables) > 0 {
+		addOutput(buf, dialect, "DELETED", b.returnings)
+		buf.WriteByte(' ')
+	}
 
 	buf.WriteString("FROM ")
-	for i, t := range b.ftables {
+	for i, t := range ftables {
 		if i > 0 {
-			buf.WriteString(", ")
-		}
-		buf.WriteString(dialect.Quote(t.Table))
-		if t.Alias != "" {
-			buf.WriteString(" AS ")
-			buf.WriteString(dialect.Quote(t.Alias))
+			if using && i == 1 {
+				buf.WriteString(" USING ")
+			} else {
+				buf.WriteString(", ")
+			}
 		}
+		t.Build(buf, ab)
+	}
+	if len(dtables) == 0 {
+		addOutput(buf, dialect, "DELETED", b.returnings)
 	}
 
 	// Join
 	for _, join := range b.joins {
-		join.Build(buf, dialect)
+		join.Build(buf, ab)
 	}
 
 	// Where
@@ -190,13 +383,13 @@ func (b *DeleteBuilder) Build() (sql string, args []interface{}) {
 			expr = And(b.where...)
 		}
 
-		ab := NewArgsBuilder(dialect)
 		buf.WriteString(" WHERE ")
 		buf.WriteString(expr.Build(ab))
-		args = ab.Args()
 	}
+	addReturning(buf, dialect, b.returnings)
 
 	sql = buf.String()
+	args = ab.Args()
 	putBuffer(buf)
 	return intercept(b.intercept, sql, args)
 }
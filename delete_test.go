@@ -48,3 +48,17 @@ func ExampleDeleteBuilder() {
 	// DELETE FROM "table" WHERE ("c1"=$1 AND "c2" IS NOT NULL AND "c3"<$2 AND ("c4" LIKE $3 OR "c5" BETWEEN $4 AND $5))
 	// [123 123 %value% 100 200]
 }
+
+func ExampleDeleteBuilder_JoinCond() {
+	del := Delete().From("users", "u").
+		JoinCond("bans", "b", On("b.user_id", "u.id"), Less("b.expired_at", 100)).
+		Where(Equal("u.status", 0))
+
+	sql, args := del.SetDialect(MySQL).Build()
+	fmt.Println(sql)
+	fmt.Println(args)
+
+	// Output:
+	// DELETE `u` FROM `users` AS `u` JOIN `bans` AS `b` ON `b`.`user_id`=`u`.`id` AND `b`.`expired_at`<? WHERE `u`.`status`=?
+	// [100 0]
+}
@@ -14,10 +14,7 @@
 
 package sqlx
 
-import (
-	"fmt"
-	"strings"
-)
+import "fmt"
 
 // Dialect represents a dialect of the SQL.
 type Dialect interface {
@@ -32,10 +29,14 @@ type Dialect interface {
 
 	// Quote returns the quotation format of sql string,
 	// such as `s` for MySQL and "s" for PostgreSQL.
+	//
+	// The embedded quotation characters must be escaped, and the raw
+	// expression should be passed by Raw instead of Quote.
 	Quote(s string) string
 
 	// LimitOffset returns the LIMIT OFFSET statement,
-	// such as "LIMIT n" or "LIMIT n OFFSET m" for MySQL and PostgreSQL.
+	// such as "LIMIT n" or "LIMIT n OFFSET m" for MySQL and PostgreSQL,
+	// and "OFFSET m ROWS FETCH NEXT n ROWS ONLY" for SQL Server and Oracle.
 	LimitOffset(limit, offset int64) string
 }
 
@@ -63,6 +64,8 @@ func init() {
 	RegisterDialect(MySQL, false)
 	RegisterDialect(Sqlite3, false)
 	RegisterDialect(Postgres, false)
+	RegisterDialect(MSSQL, false)
+	RegisterDialect(Oracle, false)
 }
 
 // DefaultDialect is the default dialect.
@@ -73,11 +76,15 @@ var (
 	MySQL    Dialect = dialect{mysqlDialect}
 	Sqlite3  Dialect = dialect{sqlite3Dialect}
 	Postgres Dialect = dialect{pqDialect}
+	MSSQL    Dialect = dialect{mssqlDialect}
+	Oracle   Dialect = dialect{oracleDialect}
 )
 
 const (
 	pqDialect      = "postgres"
 	mysqlDialect   = "mysql"
+	mssqlDialect   = "sqlserver"
+	oracleDialect  = "oracle"
 	sqlite3Dialect = "sqlite3"
 )
 
@@ -93,94 +100,82 @@ func (d dialect) Placeholder(i int) string {
 	switch d.name {
 	case pqDialect:
 		return fmt.Sprintf("$%d", i)
+	case mssqlDialect:
+		return fmt.Sprintf("@p%d", i)
+	case oracleDialect:
+		return fmt.Sprintf(":%d", i)
 	case mysqlDialect, sqlite3Dialect:
 		return "?"
 	}
 
-	panic(fmt.Errorf("unknown sql dialect '%s'", d.name))
-}
-
-func (d dialect) isQuoted(s string) bool {
-	switch d.name {
-	case pqDialect, sqlite3Dialect:
-		return strings.IndexByte(s, '"') >= 0
-	case mysqlDialect:
-		return strings.IndexByte(s, '`') >= 0
-	}
-	panic(fmt.Errorf("unknown sql dialect '%s'", d.name))
+	panic(buildErrorf("Dialect", ErrUnknownDialect, "unknown sql dialect '%s'", d.name))
 }
 
-func (d dialect) quoteByDialect(s string) string {
+func (d dialect) quoter() identQuoter {
 	switch d.name {
-	case pqDialect, sqlite3Dialect:
-		return fmt.Sprintf(`"%s"`, s)
+	case pqDialect:
+		return identQuoter{Open: '"', Close: '"', FoldLower: true}
+	case sqlite3Dialect, oracleDialect:
+		return identQuoter{Open: '"', Close: '"'}
 	case mysqlDialect:
-		return fmt.Sprintf("`%s`", s)
-	}
-
-	panic(fmt.Errorf("unknown sql dialect '%s'", d.name))
-}
-
-func (d dialect) isNumber(s string) bool {
-	for i, _len := 0, len(s); i < _len; i++ {
-		if b := s[i]; b != '.' && (b < '0' || b > '9') {
-			return false
-		}
-	}
-	return true
-}
-
-func (d dialect) quote(s string) string {
-	if s == "*" || d.isNumber(s) || d.isQuoted(s) {
-		return s
-	}
-
-	if strings.IndexByte(s, '.') < 0 {
-		return d.quoteByDialect(s)
-	}
-
-	vs := strings.Split(s, ".")
-	for i, v := range vs {
-		vs[i] = d.quoteByDialect(v)
+		return identQuoter{Open: '`', Close: '`'}
+	case mssqlDialect:
+		return identQuoter{Open: '[', Close: ']'}
 	}
 
-	return strings.Join(vs, ".")
+	panic(buildErrorf("Dialect", ErrUnknownDialect, "unknown sql dialect '%s'", d.name))
 }
 
 func (d dialect) Quote(item string) string {
-	s := strings.TrimSpace(item)
-	if strings.IndexByte(s, ' ') >= 0 {
-		return s
-	}
-
-	rightIndex := strings.IndexByte(s, ')')
-	if rightIndex < 0 {
-		return d.quote(s)
-	}
-
-	leftIndex := strings.LastIndexByte(s, '(') + 1
-	if leftIndex < 1 {
-		panic(fmt.Errorf("invalid sql syntax: %s", item))
-	}
-
-	return strings.Join([]string{
-		s[:leftIndex],
-		d.quote(s[leftIndex:rightIndex]),
-		s[rightIndex:],
-	}, "")
+	return d.quoter().Quote(item)
 }
 
 func (d dialect) LimitOffset(limit, offset int64) string {
 	switch d.name {
 	case pqDialect, mysqlDialect, sqlite3Dialect:
 		if limit < 0 {
-			panic("sqlx: the limit must be a positive integer")
+			panic(buildError("Dialect", ErrInvalidLimit))
 		}
 		if offset == 0 {
 			return fmt.Sprintf("LIMIT %d", limit)
 		}
 		return fmt.Sprintf("LIMIT %d OFFSET %d", limit, offset)
+
+	case mssqlDialect:
+		if limit < 0 {
+			panic(buildError("Dialect", ErrInvalidLimit))
+		}
+		if limit == 0 {
+			return fmt.Sprintf("OFFSET %d ROWS", offset)
+		}
+		return fmt.Sprintf("OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", offset, limit)
+
+	case oracleDialect:
+		if limit < 0 {
+			panic(buildError("Dialect", ErrInvalidLimit))
+		}
+		if limit == 0 {
+			return fmt.Sprintf("OFFSET %d ROWS", offset)
+		} else if offset == 0 {
+			return fmt.Sprintf("FETCH FIRST %d ROWS ONLY", limit)
+		}
+		return fmt.Sprintf("OFFSET %d ROWS FETCH NEXT %d ROWS ONLY", offset, limit)
+	}
+
+	panic(buildErrorf("Dialect", ErrUnknownDialect, "unknown sql dialect '%s'", d.name))
+}
+
+func (d dialect) Capabilities() Capabilities {
+	if c, ok := capabilities[d.name]; ok {
+		return c
 	}
+	panic(buildErrorf("Dialect", ErrUnknownDialect, "unknown sql dialect '%s'", d.name))
+}
 
-	panic(fmt.Errorf("unknown sql dialect '%s'", d.name))
+// tableAliasKeyword returns the keyword between the table and its alias.
+func tableAliasKeyword(d Dialect) string {
+	if GetCapabilities(d).TableAliasAS {
+		return " AS "
+	}
+	return " "
 }
@@ -29,11 +29,8 @@ func TestMySQLDialect(t *testing.T) {
 	if s := MySQL.LimitOffset(123, 456); s != "LIMIT 123 OFFSET 456" {
 		t.Errorf("expected 'LIMIT 123 OFFSET 456', got '%s'", s)
 	}
-	if s := MySQL.Quote("SUM(`number`)"); s != "SUM(`number`)" {
-		t.Errorf("expected 'SUM(`number`)', got '%s'", s)
-	}
-	if s := MySQL.Quote("SUM(number)"); s != "SUM(`number`)" {
-		t.Errorf("expected 'SUM(`number`)', got '%s'", s)
+	if s := MySQL.Quote("SUM(number)"); s != "`SUM(number)`" {
+		t.Errorf("expected '`SUM(number)`', got '%s'", s)
 	}
 }
 
@@ -66,3 +63,58 @@ func TestPostgreSQLDialect(t *testing.T) {
 		t.Errorf("expected 'LIMIT 123 OFFSET 456', got '%s'", s)
 	}
 }
+
+func TestMSSQLDialect(t *testing.T) {
+	if s := MSSQL.Placeholder(2); s != "@p2" {
+		t.Errorf("expected '@p2', got '%s'", s)
+	}
+	if s := MSSQL.Quote("time"); s != "[time]" {
+		t.Errorf("expected '[time]', got '%s'", s)
+	}
+	if s := MSSQL.Quote("t.time"); s != "[t].[time]" {
+		t.Errorf("expected '[t].[time]', got '%s'", s)
+	}
+	if s := MSSQL.LimitOffset(123, 0); s != "OFFSET 0 ROWS FETCH NEXT 123 ROWS ONLY" {
+		t.Errorf("expected 'OFFSET 0 ROWS FETCH NEXT 123 ROWS ONLY', got '%s'", s)
+	}
+	if s := MSSQL.LimitOffset(123, 456); s != "OFFSET 456 ROWS FETCH NEXT 123 ROWS ONLY" {
+		t.Errorf("expected 'OFFSET 456 ROWS FETCH NEXT 123 ROWS ONLY', got '%s'", s)
+	}
+	if s := MSSQL.LimitOffset(0, 456); s != "OFFSET 456 ROWS" {
+		t.Errorf("expected 'OFFSET 456 ROWS', got '%s'", s)
+	}
+}
+
+func TestOracleDialect(t *testing.T) {
+	if s := Oracle.Placeholder(2); s != ":2" {
+		t.Errorf("expected ':2', got '%s'", s)
+	}
+	if s := Oracle.Quote("time"); s != `"time"` {
+		t.Errorf(`expected '"time"', got '%s'`, s)
+	}
+	if s := Oracle.LimitOffset(123, 0); s != "FETCH FIRST 123 ROWS ONLY" {
+		t.Errorf("expected 'FETCH FIRST 123 ROWS ONLY', got '%s'", s)
+	}
+	if s := Oracle.LimitOffset(123, 456); s != "OFFSET 456 ROWS FETCH NEXT 123 ROWS ONLY" {
+		t.Errorf("expected 'OFFSET 456 ROWS FETCH NEXT 123 ROWS ONLY', got '%s'", s)
+	}
+
+	sel := Select("A.id").From("table", "A").JoinLeft("table2", "B", On("A.id", "B.id")).
+		Limit(10).SetDialect(Oracle)
+	expected := `SELECT "A"."id" AS "id" FROM "table" "A" LEFT JOIN "table2" "B" ON "A"."id"="B"."id" FETCH FIRST 10 ROWS ONLY`
+	if s := sel.String(); s != expected {
+		t.Errorf("expected '%s', got '%s'", expected, s)
+	}
+
+	update := Update().Table("table", "A").Set(Assign("A.c1", 1)).SetDialect(Oracle)
+	expected = `UPDATE "table" "A" SET "A"."c1"=:1`
+	if s := update.String(); s != expected {
+		t.Errorf("expected '%s', got '%s'", expected, s)
+	}
+
+	del := Delete().From("table", "A").Where(Equal("A.c1", 1)).SetDialect(Oracle)
+	expected = `DELETE FROM "table" "A" WHERE "A"."c1"=:1`
+	if s := del.String(); s != expected {
+		t.Errorf("expected '%s', got '%s'", expected, s)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+)
+
+// testDriver is the fake driver shared by the tests, which returns the canned
+// result sets for the queries in turn, and records the executed statements
+// and the ends of the transactions.
+type testDriver struct {
+	// Columns and Results are the columns and the rows of the result sets.
+	// If Repeat is true, the first result set is returned for every query.
+	Columns []string
+	Results [][][]driver.Value
+	Repeat  bool
+
+	// OnExec is called before executing the statement if set.
+	OnExec func(query string)
+
+	Execs []string // The executed statements with their arguments.
+	Txs   []string // "commit" or "rollback" for each transaction.
+}
+
+// newIDDriver returns a fake driver, which returns the rows of the column
+// "id" for each query in turn.
+func newIDDriver(results ...[]int64) *testDriver {
+	d := &testDriver{Columns: []string{"id"}, Results: make([][][]driver.Value, len(results))}
+	for i, ids := range results {
+		d.Results[i] = make([][]driver.Value, len(ids))
+		for j, id := range ids {
+			d.Results[i][j] = []driver.Value{id}
+		}
+	}
+	return d
+}
+
+// DB returns a new DB with the dialect, which uses the fake driver.
+func (d *testDriver) DB(dialect Dialect) *DB {
+	return &DB{DB: sql.OpenDB(d), Dialect: dialect}
+}
+
+func (d *testDriver) Open(string) (driver.Conn, error)             { return testConn{d}, nil }
+func (d *testDriver) Connect(context.Context) (driver.Conn, error) { return testConn{d}, nil }
+func (d *testDriver) Driver() driver.Driver                        { return d }
+
+type testConn struct{ d *testDriver }
+
+func (c testConn) Prepare(query string) (driver.Stmt, error) { return testStmt{c.d, query}, nil }
+func (c testConn) Close() error                              { return nil }
+func (c testConn) Begin() (driver.Tx, error)                 { return testTx(c), nil }
+
+type testTx struct{ d *testDriver }
+
+func (tx testTx) Commit() error   { tx.d.Txs = append(tx.d.Txs, "commit"); return nil }
+func (tx testTx) Rollback() error { tx.d.Txs = append(tx.d.Txs, "rollback"); return nil }
+
+type testStmt struct {
+	d     *testDriver
+	query string
+}
+
+func (s testStmt) Close() error  { return nil }
+func (s testStmt) NumInput() int { return -1 }
+
+func (s testStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if s.d.OnExec != nil {
+		s.d.OnExec(s.query)
+	}
+	s.d.Execs = append(s.d.Execs, fmt.Sprintf("%s %v", s.query, args))
+	return driver.RowsAffected(1), nil
+}
+
+func (s testStmt) Query([]driver.Value) (driver.Rows, error) {
+	if len(s.d.Results) == 0 {
+		return nil, fmt.Errorf("no result set for the query: %s", s.query)
+	}
+
+	rows := s.d.Results[0]
+	if !s.d.Repeat {
+		s.d.Results = s.d.Results[1:]
+	}
+	return &testRows{columns: s.d.Columns, rows: rows}, nil
+}
+
+type testRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *testRows) Columns() []string { return r.columns }
+func (r *testRows) Close() error      { return nil }
+func (r *testRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
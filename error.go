@@ -0,0 +1,88 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Predefine some causes of BuildError.
+var (
+	ErrNoTable            = errors.New("no table name")
+	ErrNoColumns          = errors.New("no columns")
+	ErrNoValues           = errors.New("no values")
+	ErrInconsistentValues = errors.New("the numbers of the values are not consistent")
+	ErrInvalidLimit       = errors.New("the limit must be a positive integer")
+	ErrUnknownDialect     = errors.New("unknown sql dialect")
+	ErrInvalidStatement   = errors.New("invalid statement")
+)
+
+// BuildError represents the error to build the SQL statement, which is
+// returned by BuildE, Exec and Query of the builders, but panicked by Build.
+// For example,
+//
+//	if _, _, err := Select("id").BuildE(); err != nil {
+//		if be, ok := err.(BuildError); ok && be.Err == ErrNoTable {
+//			// ...
+//		}
+//	}
+//
+// For Go1.13+, errors.Is(err, ErrNoTable) also works.
+type BuildError struct {
+	// Builder is the name of the builder or the component, such as
+	// "SelectBuilder", "InsertBuilder" and "Dialect".
+	Builder string
+
+	// Err is the cause, which is one of the predefined errors, such as ErrNoTable.
+	Err error
+
+	// Detail is the optional detail of the error.
+	Detail string
+}
+
+func buildError(builder string, err error) BuildError {
+	return BuildError{Builder: builder, Err: err}
+}
+
+func buildErrorf(builder string, err error, format string, args ...interface{}) BuildError {
+	return BuildError{Builder: builder, Err: err, Detail: fmt.Sprintf(format, args...)}
+}
+
+// Error implements the interface error.
+func (e BuildError) Error() string {
+	if e.Detail == "" {
+		return e.Builder + ": " + e.Err.Error()
+	}
+	return e.Builder + ": " + e.Detail
+}
+
+// Unwrap returns the cause of the error.
+func (e BuildError) Unwrap() error { return e.Err }
+
+// recoverBuildError recovers the panic of BuildError or UnsupportedError
+// and assigns it to err, but re-panics the others, such as runtime.Error.
+func recoverBuildError(err *error) {
+	if r := recover(); r != nil {
+		switch e := r.(type) {
+		case BuildError:
+			*err = e
+		case UnsupportedError:
+			*err = e
+		default:
+			panic(r)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import "testing"
+
+func TestBuildError(t *testing.T) {
+	expectBuildError := func(name string, err error, cause error) {
+		if be, ok := err.(BuildError); !ok {
+			t.Errorf("%s: expect a BuildError, but got %v", name, err)
+		} else if be.Err != cause {
+			t.Errorf("%s: expect the cause '%v', but got '%v'", name, cause, be.Err)
+		}
+	}
+
+	_, _, err := Select("id").BuildE()
+	expectBuildError("select", err, ErrNoTable)
+
+	_, _, err = Select("id").From("t").Where(Expr("a=? AND b=?", 1)).BuildE()
+	expectBuildError("expr", err, ErrInvalidStatement)
+
+	_, _, err = Select("id").From("t").Limit(-1).Offset(1).BuildE()
+	expectBuildError("limit", err, ErrInvalidLimit)
+
+	_, _, err = Insert().Into("t").Columns("a", "b").Values(1, 2).Values(3).BuildE()
+	expectBuildError("insert", err, ErrInconsistentValues)
+	if err.Error() != "InsertBuilder: the numbers of the values for INSERT are not consistent" {
+		t.Errorf("unexpected error message: %s", err.Error())
+	}
+
+	_, err = Update("t").Where(Equal("id", 1)).Exec()
+	expectBuildError("update", err, ErrNoValues)
+
+	_, err = Delete().Where(Equal("id", 1)).Exec()
+	expectBuildError("delete", err, ErrNoTable)
+
+	_, err = Table("t").Exec()
+	expectBuildError("table", err, ErrNoColumns)
+
+	_, err = Union(Select("id").From("t"), Select("id")).Query()
+	expectBuildError("union", err, ErrNoTable)
+
+	var id int
+	err = Select("id").QueryRow().Scan(&id)
+	expectBuildError("row", err, ErrNoTable)
+
+	err = Insert().Into("t").Columns("id").Values(1).Returning("id").
+		QueryRow().ScanStruct(&struct{ ID int }{})
+	if _, ok := err.(UnsupportedError); !ok {
+		t.Errorf("expect an UnsupportedError, but got %v", err)
+	}
+
+	_, err = Insert().Columns("id").Values(1).Values(2).MaxRows(1).ExecBatch()
+	expectBuildError("batch", err, ErrNoTable)
+
+	// The errors occurred when chaining the builders are reported when building.
+	type builderE interface {
+		BuildE() (string, []interface{}, error)
+	}
+
+	sub := Select("id").From("t")
+	chains := map[string]builderE{
+		"from select":       Select("id").FromSelect(sub, ""),
+		"from nil select":   Select("id").FromSelect(nil, "t"),
+		"join select":       Select("id").From("t").JoinSelect(sub, ""),
+		"join left select":  Select("id").From("t").JoinLeftSelect(nil, "s"),
+		"with":              Select("id").From("t").With("", sub),
+		"with recursive":    Select("id").From("t").WithRecursive("r", nil),
+		"select over":       Select("id").From("t").SelectOver(Raw("ROW_NUMBER()"), nil),
+		"window":            Select("id").From("t").Window("", NewWindow()),
+		"case":              Select("id").From("t").OrderByExpr(Case().When(nil, 1)),
+		"nested":            Select("id").FromSelect(Select("id").From("t").With("", sub), "s"),
+		"union":             Union(),
+		"union nil":         NewCompoundBuilder(nil),
+		"union all":         UnionAll(sub, nil),
+		"select struct":     Select("id").From("t").SelectStruct(1),
+		"select struct ptr": SelectStruct(new(int)).From("t"),
+		"insert struct":     Insert().Into("t").Struct("id"),
+		"insert structs":    Insert().Into("t").Structs(1),
+		"insert elems":      Insert().Into("t").Structs([]int{1}),
+	}
+	for name, b := range chains {
+		_, _, err = b.BuildE()
+		expectBuildError(name, err, ErrInvalidStatement)
+	}
+
+	_, err = Union().Query()
+	expectBuildError("union query", err, ErrInvalidStatement)
+
+	err = NewCompoundBuilder(nil).QueryRow().Scan(new(int))
+	expectBuildError("union query row", err, ErrInvalidStatement)
+}
+
+func TestBuildErrorRepanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("expect a panic, but got nil")
+		}
+	}()
+
+	// The runtime error is not recovered.
+	Select("id").From("t").Where(Condition(nil)).BuildE()
+}
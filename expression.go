@@ -0,0 +1,94 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import "strings"
+
+// Expression represents a SQL expression with the arguments, which may be
+// used as the selected column, the item of ORDER BY and GROUP BY, the value
+// of the setter, and the condition.
+//
+// Condition and Raw have also implemented the interface Expression.
+type Expression interface {
+	// Build builds and returns the expression.
+	//
+	// If there are some arguments, they should be added into ArgsBuilder.
+	Build(*ArgsBuilder) string
+}
+
+type sqlExpr struct {
+	sql  string
+	args []interface{}
+}
+
+// Expr returns a new expression, which replaces each "?" in sql with
+// the placeholder of the argument in turn. If the argument is an Expression,
+// it is built and inlined instead. "??" is used as the literal "?".
+// For example,
+//
+//	Expr("COALESCE(?, ?)", Ident("nickname"), "anonymous")
+//	// MySQL:      COALESCE(`nickname`, ?)
+//	// PostgreSQL: COALESCE("nickname", $1)
+//
+// Notice: sql must not contain the input from the untrusted user.
+func Expr(sql string, args ...interface{}) Expression {
+	return sqlExpr{sql: sql, args: args}
+}
+
+func (e sqlExpr) Build(ab *ArgsBuilder) string {
+	var index int
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	sql := e.sql
+	for {
+		i := strings.IndexByte(sql, '?')
+		if i < 0 {
+			buf.WriteString(sql)
+			break
+		}
+
+		buf.WriteString(sql[:i])
+		if i+1 < len(sql) && sql[i+1] == '?' {
+			buf.WriteByte('?')
+			sql = sql[i+2:]
+			continue
+		}
+		sql = sql[i+1:]
+
+		if index >= len(e.args) {
+			panic(buildErrorf("Expr", ErrInvalidStatement,
+				"missing the argument #%d of the expression '%s'", index+1, e.sql))
+		}
+
+		buf.WriteString(buildValue(ab, e.args[index]))
+		index++
+	}
+
+	if index != len(e.args) {
+		panic(buildErrorf("Expr", ErrInvalidStatement,
+			"the expression '%s' has %d placeholders, but got %d arguments",
+			e.sql, index, len(e.args)))
+	}
+	return buf.String()
+}
+
+type identExpr string
+
+func (e identExpr) Build(ab *ArgsBuilder) string { return ab.Quote(string(e)) }
+
+// Ident returns an expression of the identifier, such as the column
+// "table.column", which is quoted by the dialect.
+func Ident(name string) Expression { return identExpr(name) }
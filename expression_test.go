@@ -0,0 +1,67 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleExpr() {
+	s := Select("id").SelectExpr(Expr("COALESCE(?, ?)", Ident("nickname"), "anonymous"), "name").
+		SelectExpr(Expr("? * ?", Ident("price"), Ident("qty")), "total").From("orders").
+		Where(Greater("price", 10)).GroupByExpr(Expr("DATE(?)", Ident("created_at"))).
+		OrderByExpr(Expr("CASE WHEN ?=? THEN 0 ELSE 1 END", Ident("status"), 2)).OrderBy("id")
+
+	update := Update().Table("orders").Set(
+		AssignExpr("name", Expr("LOWER(?)", Ident("name"))),
+		AssignExpr("total", Expr("? * ? + ?", Ident("price"), Ident("qty"), 5)),
+		Assign("status", 1),
+	).Where(Equal("id", 123))
+
+	sql1, args1 := s.SetDialect(Postgres).Build()
+	sql2, args2 := update.SetDialect(Postgres).Build()
+
+	fmt.Println(sql1)
+	fmt.Println(args1)
+
+	fmt.Println(sql2)
+	fmt.Println(args2)
+
+	// Output:
+	// SELECT "id", COALESCE("nickname", $1) AS "name", "price" * "qty" AS "total" FROM "orders" WHERE "price">$2 GROUP BY DATE("created_at") ORDER BY CASE WHEN "status"=$3 THEN 0 ELSE 1 END, "id"
+	// [anonymous 10 2]
+	// UPDATE "orders" SET "name"=LOWER("name"), "total"="price" * "qty" + $1, "status"=$2 WHERE "id"=$3
+	// [5 1 123]
+}
+
+func TestExpr(t *testing.T) {
+	ab := NewArgsBuilder(Postgres)
+	expr := Expr("? ?? ?", Ident("data"), Expr("LOWER(?)", "KEY"))
+	if sql := expr.Build(ab); sql != `"data" ? LOWER($1)` {
+		t.Errorf("unexpected sql '%s'", sql)
+	} else if args := ab.Args(); len(args) != 1 || args[0] != "KEY" {
+		t.Errorf("unexpected args %v", args)
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("expect a panic for the missing argument")
+			}
+		}()
+		Expr("?=?", Ident("a")).Build(NewArgsBuilder(MySQL))
+	}()
+}
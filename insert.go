@@ -19,7 +19,6 @@ import (
 	"context"
 	"database/sql"
 	"reflect"
-	"strings"
 
 	"github.com/xgfone/cast"
 )
@@ -44,56 +43,163 @@ type InsertBuilder struct {
 	table   string
 	columns []string
 	values  [][]interface{}
+	query   Builder
+
+	upsert    bool
+	upserts   []Setter
+	conflicts []string
+
+	returnings []string
+
+	maxArgs int
+	maxRows int
+
+	// err is the error occurred when adding the values,
+	// which is reported when building.
+	err error
+
+	immutable bool
 }
 
+const (
+	insertVerb  = "INSERT"
+	ignoreVerb  = "INSERT IGNORE"
+	replaceVerb = "REPLACE"
+)
+
 // Into sets the table name with "INSERT INTO".
 func (b *InsertBuilder) Into(table string) *InsertBuilder {
-	b.verb = "INSERT"
+	b = b.writable()
+	b.verb = insertVerb
 	b.table = table
 	return b
 }
 
 // IgnoreInto sets the table name with "INSERT IGNORE INTO".
+//
+// For the dialect supporting UpsertOnConflict, such as PostgreSQL and SQLite,
+// it is translated to "INSERT INTO ... ON CONFLICT DO NOTHING".
 func (b *InsertBuilder) IgnoreInto(table string) *InsertBuilder {
-	b.verb = "INSERT IGNORE"
+	b = b.writable()
+	b.verb = ignoreVerb
 	b.table = table
 	return b
 }
 
 // ReplaceInto sets the table name with "REPLACE INTO".
 //
-// REPLACE INTO is a MySQL extension to the SQL standard.
+// REPLACE INTO is a MySQL extension to the SQL standard, which is also
+// supported by SQLite.
 func (b *InsertBuilder) ReplaceInto(table string) *InsertBuilder {
-	b.verb = "REPLACE"
+	b = b.writable()
+	b.verb = replaceVerb
 	b.table = table
 	return b
 }
 
+// OnConflict sets the conflict target columns of UPSERT, which is used by
+// "ON CONFLICT (columns...)" for PostgreSQL and SQLite, and is ignored
+// by MySQL that uses the primary key and the unique indexes.
+//
+// It should be used with DoUpdate or DoNothing. For example,
+//
+//	Insert().Into("table").Columns("id", "name", "count").Values(1, "a", 1).
+//		OnConflict("id").DoUpdate(Inserted("name"), Incr("count"))
+//
+// The above is built as follow:
+//
+//	MySQL:      ... ON DUPLICATE KEY UPDATE `name`=VALUES(`name`), `count`=`count`+1
+//	PostgreSQL: ... ON CONFLICT ("id") DO UPDATE SET "name"=EXCLUDED."name", "count"="table"."count"+1
+//
+// For ON CONFLICT, the column read by Incr, Decr, Add, Sub, Mul and Div is
+// qualified by the table, because it is ambiguous with that of EXCLUDED.
+// So qualify it explicitly in the custom setter, such as
+// AssignExpr("count", Expr("? + 1", Ident("table.count"))).
+func (b *InsertBuilder) OnConflict(columns ...string) *InsertBuilder {
+	b = b.writable()
+	b.conflicts = columns
+	return b
+}
+
+// DoUpdate sets the setters to update the existed row when the inserted row
+// conflicts with it, that's, "ON DUPLICATE KEY UPDATE setters..." for MySQL,
+// and "ON CONFLICT (columns...) DO UPDATE SET setters..." for PostgreSQL
+// and SQLite, which requires the conflict columns set by OnConflict.
+func (b *InsertBuilder) DoUpdate(setters ...Setter) *InsertBuilder {
+	b = b.writable()
+	b.upsert = true
+	b.upserts = setters
+	return b
+}
+
+// DoNothing ignores the inserted row when it conflicts with the existed row,
+// that's, "ON CONFLICT [(columns...)] DO NOTHING" for PostgreSQL and SQLite,
+// and "ON DUPLICATE KEY UPDATE column=column" for MySQL, which uses the first
+// conflict column or the first inserted column.
+func (b *InsertBuilder) DoNothing() *InsertBuilder {
+	b = b.writable()
+	b.upsert = true
+	b.upserts = nil
+	return b
+}
+
 // Columns sets the inserted columns.
 func (b *InsertBuilder) Columns(columns ...string) *InsertBuilder {
+	b = b.writable()
 	b.columns = columns
 	return b
 }
 
 // Values appends the inserting values.
+//
+// If the number of the values is not consistent with the former,
+// the values are ignored and the error is reported when building.
 func (b *InsertBuilder) Values(values ...interface{}) *InsertBuilder {
-	if len(b.values) > 0 {
-		if len(b.values[0]) != len(values) {
-			panic("InsertBuilder: the numbers of the values for INSERT are not consistent")
-		}
+	b = b.writable()
+	if len(b.values) > 0 && len(b.values[0]) != len(values) {
+		return b.setError(errInconsistentInsertValues)
 	}
 	b.values = append(b.values, values)
 	return b
 }
 
+var errInconsistentInsertValues = buildErrorf("InsertBuilder", ErrInconsistentValues,
+	"the numbers of the values for INSERT are not consistent")
+
+// setError records the first error occurred when adding the values.
+func (b *InsertBuilder) setError(err error) *InsertBuilder {
+	if b.err == nil {
+		b.err = err
+	}
+	return b
+}
+
+// Select sets the query as the source of the inserted rows instead of VALUES,
+// which is built as "INSERT INTO table (columns...) SELECT ...". For example,
+//
+//	Insert().Into("archive").Columns("id", "name").
+//		Select(Selects("id", "name").From("users").Where(Less("created_at", t)))
+//
+// If query implements the interface NestedBuilder, such as SelectBuilder,
+// it is built with the dialect of the INSERT statement and its arguments
+// are numbered after those of the INSERT statement.
+//
+// For SQLite with UPSERT, query must be a SelectBuilder, and "WHERE true"
+// is added if it has no WHERE, which avoids ON CONFLICT being parsed
+// as the join constraint.
+func (b *InsertBuilder) Select(query Builder) *InsertBuilder {
+	b = b.writable()
+	b.query = query
+	return b
+}
+
 // NamedValues is the same as Values. But it will set it if the columns
 // are not set.
 func (b *InsertBuilder) NamedValues(values ...sql.NamedArg) *InsertBuilder {
+	b = b.writable()
 	_len := len(values)
-	if len(b.values) > 0 {
-		if len(b.values[0]) != _len {
-			panic("InsertBuilder: the numbers of the values for INSERT are not consistent")
-		}
+	if len(b.values) > 0 && len(b.values[0]) != _len {
+		return b.setError(errInconsistentInsertValues)
 	}
 
 	cs := make([]string, _len)
@@ -130,50 +236,181 @@ func (b *InsertBuilder) Struct(s interface{}) *InsertBuilder {
 
 		v = v.Elem()
 		if v.Kind() != reflect.Struct {
-			panic("not a pointer to struct")
+			b = b.writable()
+			return b.setError(buildErrorf("InsertBuilder", ErrInvalidStatement,
+				"%T is not a pointer to struct", s))
 		}
 	case reflect.Struct:
 	default:
-		panic("not a struct")
+		b = b.writable()
+		return b.setError(buildErrorf("InsertBuilder", ErrInvalidStatement, "%T is not a struct", s))
 	}
 
-	vt := v.Type()
-	_len := v.NumField()
-	args := make([]sql.NamedArg, 0, _len)
-	for i := 0; i < _len; i++ {
-		vft := vt.Field(i)
-		name := vft.Name
-
-		var notZero bool
-		tag := vft.Tag.Get("sql")
-		if index := strings.IndexByte(tag, ','); index > -1 {
-			if strings.TrimSpace(tag[index+1:]) == "omitempty" {
-				notZero = true
-			}
-			tag = strings.TrimSpace(tag[:index])
-		}
-
-		if tag == "-" {
-			continue
-		} else if tag != "" {
-			name = tag
-		}
-
-		vf := v.Field(i)
+	fields := getStructInfo(v.Type()).Fields
+	args := make([]sql.NamedArg, 0, len(fields))
+	for _, field := range fields {
+		vf := v.Field(field.Index)
 		if !vf.IsValid() {
 			continue
-		} else if notZero && cast.IsZero(vf.Interface()) {
+		} else if field.OmitEmpty && cast.IsZero(vf.Interface()) {
 			continue
 		} else if vf.Kind() == reflect.Ptr {
 			vf = vf.Elem()
 		}
 
-		args = append(args, sql.NamedArg{Name: name, Value: vf.Interface()})
+		args = append(args, sql.NamedArg{Name: field.Name, Value: vf.Interface()})
 	}
 
 	return b.NamedValues(args...)
 }
 
+// Structs is the same as Struct, but inserts all the elements of the slice
+// of structs or pointers to structs as the multi-row VALUES, which uses
+// the same columns extracted from the struct type, and the nil elements
+// are skipped. The field with "omitempty" is omitted only if it is zero
+// in all the elements. For example,
+//
+//	type User struct {
+//		ID   int    `sql:"id,omitempty"`
+//		Name string `sql:"name"`
+//		Age  int    `sql:"-"`
+//	}
+//
+//	users := []User{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}
+//	Insert().Into("user").Structs(users)
+//	// INSERT INTO `user` (`id`, `name`) VALUES (?, ?), (?, ?)
+//
+//	users = []User{{Name: "a"}, {Name: "b"}}
+//	Insert().Into("user").Structs(users)
+//	// INSERT INTO `user` (`name`) VALUES (?), (?)
+//
+// If the columns have been set, the values of the fields are extracted
+// by the column names in turn, and every column must be a struct field.
+func (b *InsertBuilder) Structs(slice interface{}) *InsertBuilder {
+	if slice == nil {
+		return b
+	}
+
+	b = b.writable()
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return b.setError(buildErrorf("InsertBuilder", ErrInvalidStatement,
+			"%T is not a slice of structs", slice))
+	}
+
+	et := v.Type().Elem()
+	isPtr := et.Kind() == reflect.Ptr
+	if isPtr {
+		et = et.Elem()
+	}
+	if et.Kind() != reflect.Struct {
+		return b.setError(buildErrorf("InsertBuilder", ErrInvalidStatement,
+			"%T is not a slice of structs", slice))
+	}
+
+	elems := make([]reflect.Value, 0, v.Len())
+	for i, _len := 0, v.Len(); i < _len; i++ {
+		ev := v.Index(i)
+		if isPtr {
+			if ev.IsNil() {
+				continue
+			}
+			ev = ev.Elem()
+		}
+		elems = append(elems, ev)
+	}
+
+	info := getStructInfo(et)
+	indexes := make([]int, 0, len(info.Fields))
+	if len(b.columns) == 0 {
+		b.columns = make([]string, 0, len(info.Fields))
+		for _, field := range info.Fields {
+			if field.OmitEmpty && isZeroField(elems, field.Index) {
+				continue
+			}
+			b.columns = append(b.columns, field.Name)
+			indexes = append(indexes, field.Index)
+		}
+	} else {
+		for _, column := range b.columns {
+			index, ok := info.Indexes[column]
+			if !ok {
+				return b.setError(buildErrorf("InsertBuilder", ErrInconsistentValues,
+					"the column '%s' is not a field of %s", column, et))
+			}
+			indexes = append(indexes, index)
+		}
+	}
+
+	if len(b.values) > 0 && len(b.values[0]) != len(indexes) {
+		return b.setError(errInconsistentInsertValues)
+	}
+
+	for _, ev := range elems {
+		values := make([]interface{}, len(indexes))
+		for j, index := range indexes {
+			vf := ev.Field(index)
+			if vf.Kind() == reflect.Ptr {
+				if vf.IsNil() {
+					continue
+				}
+				vf = vf.Elem()
+			}
+			values[j] = vf.Interface()
+		}
+		b.values = append(b.values, values)
+	}
+
+	return b
+}
+
+// isZeroField reports whether the field is zero in all the structs.
+func isZeroField(structs []reflect.Value, index int) bool {
+	for _, s := range structs {
+		if !cast.IsZero(s.Field(index).Interface()) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returning sets the columns returned by the INSERT statement,
+// that's, "RETURNING columns..." for PostgreSQL and SQLite,
+// and "OUTPUT INSERTED.column..." for SQL Server.
+//
+// Use Query or QueryRow instead of Exec to get the returned rows.
+func (b *InsertBuilder) Returning(columns ...string) *InsertBuilder {
+	b = b.writable()
+	b.returnings = columns
+	return b
+}
+
+// Query builds the sql and executes it by *sql.DB, which is used
+// with Returning.
+func (b *InsertBuilder) Query() (Rows, error) {
+	return b.QueryContext(context.Background())
+}
+
+// QueryContext builds the sql and executes it by *sql.DB, which is used
+// with Returning.
+func (b *InsertBuilder) QueryContext(ctx context.Context) (Rows, error) {
+	query, args, err := b.BuildE()
+	return returningBuilder{b.executor, b.returnings}.Query(ctx, query, args, err)
+}
+
+// QueryRow builds the sql and executes it by *sql.DB, which is used
+// with Returning.
+func (b *InsertBuilder) QueryRow() Row {
+	return b.QueryRowContext(context.Background())
+}
+
+// QueryRowContext builds the sql and executes it by *sql.DB, which is used
+// with Returning.
+func (b *InsertBuilder) QueryRowContext(ctx context.Context) Row {
+	query, args, err := b.BuildE()
+	return returningBuilder{b.executor, b.returnings}.QueryRow(ctx, query, args, err)
+}
+
 // Exec builds the sql and executes it by *sql.DB.
 func (b *InsertBuilder) Exec() (sql.Result, error) {
 	return b.ExecContext(context.Background())
@@ -181,28 +418,74 @@ func (b *InsertBuilder) Exec() (sql.Result, error) {
 
 // ExecContext builds the sql and executes it by *sql.DB.
 func (b *InsertBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
-	query, args := b.Build()
+	query, args, err := b.BuildE()
+	if err != nil {
+		return nil, err
+	}
 	return b.executor.ExecContext(ctx, query, args...)
 }
 
 // SetExecutor sets the executor to exec.
 func (b *InsertBuilder) SetExecutor(exec Executor) *InsertBuilder {
+	b = b.writable()
 	b.executor = exec
 	return b
 }
 
 // SetInterceptor sets the interceptor to f.
 func (b *InsertBuilder) SetInterceptor(f Interceptor) *InsertBuilder {
+	b = b.writable()
 	b.intercept = f
 	return b
 }
 
 // SetDialect resets the dialect.
 func (b *InsertBuilder) SetDialect(dialect Dialect) *InsertBuilder {
+	b = b.writable()
 	b.dialect = dialect
 	return b
 }
 
+// Clone returns a deep copy of the builder, which can be modified without
+// affecting the original, but the inserted values and the setters are shared.
+// The returned builder is always mutable even if b is immutable.
+func (b *InsertBuilder) Clone() *InsertBuilder {
+	c := *b
+	c.immutable = false
+	c.columns = append([]string(nil), b.columns...)
+	c.values = append([][]interface{}(nil), b.values...)
+	c.upserts = append([]Setter(nil), b.upserts...)
+	c.conflicts = append([]string(nil), b.conflicts...)
+	c.returnings = append([]string(nil), b.returnings...)
+	if b.query != nil {
+		c.query = cloneBuilder(b.query)
+	}
+	return &c
+}
+
+// Immutable returns an immutable clone of the builder, which is copied
+// on write like SelectBuilder.Immutable.
+func (b *InsertBuilder) Immutable() *InsertBuilder {
+	c := b.Clone()
+	c.immutable = true
+	return c
+}
+
+func (b *InsertBuilder) writable() *InsertBuilder {
+	if b.immutable {
+		return b.Immutable()
+	}
+	return b
+}
+
+// BuildE is the same as Build, but returns the error instead of panicking,
+// which is BuildError or UnsupportedError.
+func (b *InsertBuilder) BuildE() (sql string, args []interface{}, err error) {
+	defer recoverBuildError(&err)
+	sql, args = b.Build()
+	return
+}
+
 // String is the same as b.Build(), except args.
 func (b *InsertBuilder) String() string {
 	sql, _ := b.Build()
@@ -211,6 +494,10 @@ func (b *InsertBuilder) String() string {
 
 // Build builds the INSERT INTO TABLE sql statement.
 func (b *InsertBuilder) Build() (sql string, args []interface{}) {
+	if b.err != nil {
+		panic(b.err)
+	}
+
 	var valnum int
 	vallen := len(b.values)
 	if vallen > 0 {
@@ -218,18 +505,24 @@ func (b *InsertBuilder) Build() (sql string, args []interface{}) {
 	}
 
 	colnum := len(b.columns)
-	if colnum == 0 {
+	if b.query != nil {
+		if vallen > 0 {
+			panic(buildErrorf("InsertBuilder", ErrInvalidStatement,
+				"both the values and the query are set"))
+		}
+	} else if colnum == 0 {
 		if valnum == 0 {
-			panic("InsertBuilder: no columns or values")
+			panic(buildErrorf("InsertBuilder", ErrNoValues, "no columns or values"))
 		}
 	} else if valnum == 0 {
 		valnum = colnum
 	} else if colnum != valnum {
-		panic("InsertBuilder: len(columns) != len(values)")
+		panic(buildErrorf("InsertBuilder", ErrInconsistentValues,
+			"len(columns) != len(values)"))
 	}
 
 	if b.table == "" {
-		panic("InsertBuilder: no table name")
+		panic(buildError("InsertBuilder", ErrNoTable))
 	}
 
 	dialect := b.dialect
@@ -237,12 +530,170 @@ func (b *InsertBuilder) Build() (sql string, args []interface{}) {
 		dialect = DefaultDialect
 	}
 
+	caps := GetCapabilities(dialect)
+	verb, suffix := b.getVerb(dialect, caps)
+	ab := NewArgsBuilder(dialect)
 	buf := getBuffer()
-	buf.WriteString(b.verb)
-	buf.WriteString(" INTO ")
+
+	// Such as Oracle, use INSERT ALL instead of the multi-row VALUES.
+	if vallen > 1 && !caps.MultiRowValues {
+		buf.WriteString(verb)
+		buf.WriteString(" ALL")
+		for _, vs := range b.values {
+			buf.WriteByte(' ')
+			b.addInto(dialect, buf)
+			buf.WriteString(" VALUES ")
+			b.addValues(dialect, buf, ab, valnum, vs)
+		}
+		buf.WriteString(" SELECT 1 FROM DUAL")
+	} else {
+		buf.WriteString(verb)
+		buf.WriteByte(' ')
+		b.addInto(dialect, buf)
+		addOutput(buf, dialect, "INSERTED", b.returnings)
+
+		switch {
+		case b.query != nil:
+			buf.WriteByte(' ')
+			buf.WriteString(buildNested(ab, b.getQuery(dialect, suffix)))
+		case vallen == 0:
+			buf.WriteString(" VALUES ")
+			b.addValues(dialect, buf, nil, valnum, nil)
+		default:
+			buf.WriteString(" VALUES ")
+			for i, vs := range b.values {
+				if i > 0 {
+					buf.WriteString(", ")
+				}
+				b.addValues(dialect, buf, ab, valnum, vs)
+			}
+		}
+	}
+	buf.WriteString(suffix)
+
+	if b.upsert {
+		b.addUpsert(dialect, caps, buf, ab)
+	}
+	addReturning(buf, dialect, b.returnings)
+
+	sql = buf.String()
+	args = ab.Args()
+	putBuffer(buf)
+	return intercept(b.intercept, sql, args)
+}
+
+func (b *InsertBuilder) addUpsert(dialect Dialect, caps Capabilities,
+	buf *bytes.Buffer, ab *ArgsBuilder) {
+	if b.verb != insertVerb {
+		panic(buildErrorf("InsertBuilder", ErrInvalidStatement,
+			"UPSERT only supports INSERT INTO"))
+	}
+
+	switch caps.Upsert {
+	case UpsertOnDuplicateKey:
+		buf.WriteString(" ON DUPLICATE KEY UPDATE ")
+		if len(b.upserts) == 0 { // DO NOTHING
+			var column string
+			if len(b.conflicts) > 0 {
+				column = b.conflicts[0]
+			} else if len(b.columns) > 0 {
+				column = b.columns[0]
+			} else {
+				panic(buildErrorf("InsertBuilder", ErrNoColumns,
+					"no column for ON DUPLICATE KEY UPDATE"))
+			}
+
+			column = dialect.Quote(column)
+			buf.WriteString(column)
+			buf.WriteByte('=')
+			buf.WriteString(column)
+			return
+		}
+
+	case UpsertOnConflict:
+		buf.WriteString(" ON CONFLICT")
+		if len(b.conflicts) > 0 {
+			buf.WriteString(" (")
+			for i, column := range b.conflicts {
+				if i > 0 {
+					buf.WriteString(", ")
+				}
+				buf.WriteString(dialect.Quote(column))
+			}
+			buf.WriteByte(')')
+		}
+
+		if len(b.upserts) == 0 {
+			buf.WriteString(" DO NOTHING")
+			return
+		} else if len(b.conflicts) == 0 {
+			panic(buildErrorf("InsertBuilder", ErrNoColumns,
+				"no conflict columns for ON CONFLICT DO UPDATE"))
+		}
+		buf.WriteString(" DO UPDATE SET ")
+
+	default:
+		panic(unsupported(dialect, "UPSERT"))
+	}
+
+	for i, setter := range b.upserts {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		if qs, ok := setter.(qualifiedSetter); ok && caps.Upsert == UpsertOnConflict {
+			setter = qs.qualify(b.table)
+		}
+		buf.WriteString(setter.Build(ab))
+	}
+}
+
+// getQuery returns the query of INSERT ... SELECT.
+//
+// For SQLite, the ON CONFLICT clause after the query without WHERE is parsed
+// as the join constraint ON, so "WHERE true" is added to the SelectBuilder
+// without WHERE, and the other query is not supported.
+func (b *InsertBuilder) getQuery(dialect Dialect, suffix string) Builder {
+	if dialect.Name() != sqlite3Dialect || (!b.upsert && suffix == "") {
+		return b.query
+	}
+
+	query, ok := b.query.(*SelectBuilder)
+	if !ok {
+		panic(unsupported(dialect, "ON CONFLICT after the non-SelectBuilder query"))
+	} else if len(query.wheres) == 0 {
+		return query.Clone().Where(Raw("true"))
+	}
+	return query
+}
+
+// getVerb translates the INSERT verb for the dialect, and returns it
+// with the suffix appended to the statement.
+func (b *InsertBuilder) getVerb(dialect Dialect, caps Capabilities) (verb, suffix string) {
+	switch b.verb {
+	case ignoreVerb:
+		switch caps.Upsert {
+		case UpsertOnDuplicateKey:
+			return ignoreVerb, ""
+		case UpsertOnConflict:
+			return insertVerb, " ON CONFLICT DO NOTHING"
+		default:
+			panic(unsupported(dialect, "INSERT IGNORE"))
+		}
+
+	case replaceVerb:
+		if !caps.Replace {
+			panic(unsupported(dialect, "REPLACE INTO"))
+		}
+	}
+
+	return b.verb, ""
+}
+
+func (b *InsertBuilder) addInto(dialect Dialect, buf *bytes.Buffer) {
+	buf.WriteString("INTO ")
 	buf.WriteString(dialect.Quote(b.table))
 
-	if colnum > 0 {
+	if len(b.columns) > 0 {
 		buf.WriteString(" (")
 		for i, col := range b.columns {
 			if i > 0 {
@@ -252,24 +703,6 @@ func (b *InsertBuilder) Build() (sql string, args []interface{}) {
 		}
 		buf.WriteByte(')')
 	}
-
-	buf.WriteString(" VALUES ")
-	if vallen == 0 {
-		b.addValues(dialect, buf, nil, valnum, nil)
-	} else {
-		ab := NewArgsBuilder(dialect)
-		for i, vs := range b.values {
-			if i > 0 {
-				buf.WriteString(", ")
-			}
-			b.addValues(dialect, buf, ab, valnum, vs)
-		}
-		args = ab.Args()
-	}
-
-	sql = buf.String()
-	putBuffer(buf)
-	return intercept(b.intercept, sql, args)
 }
 
 func (b *InsertBuilder) addValues(dialect Dialect, buf *bytes.Buffer,
@@ -0,0 +1,175 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type txBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// MaxArgs sets the maximum number of the placeholders in an INSERT statement
+// built by Batches, which overrides the limit of the dialect.
+//
+// 0 means to use the limit of the dialect, and -1 means no limit.
+func (b *InsertBuilder) MaxArgs(n int) *InsertBuilder {
+	b = b.writable()
+	b.maxArgs = n
+	return b
+}
+
+// MaxRows sets the maximum number of the inserted rows in an INSERT statement
+// built by Batches, which overrides the limit of the dialect.
+//
+// 0 means to use the limit of the dialect, and -1 means no limit.
+func (b *InsertBuilder) MaxRows(n int) *InsertBuilder {
+	b = b.writable()
+	b.maxRows = n
+	return b
+}
+
+// Batches splits the inserted rows into several INSERT builders, each of
+// which does not exceed the limits of the placeholders and the rows set by
+// MaxArgs and MaxRows, or the capabilities of the dialect.
+//
+// If there are no limits or the rows do not exceed them, return []*InsertBuilder{b}.
+func (b *InsertBuilder) Batches() []*InsertBuilder {
+	vallen := len(b.values)
+	if vallen < 2 {
+		return []*InsertBuilder{b}
+	} else if len(b.values[0]) == 0 {
+		panic(buildErrorf("InsertBuilder", ErrNoValues, "the inserted rows are empty"))
+	}
+
+	dialect := b.dialect
+	if dialect == nil {
+		dialect = DefaultDialect
+	}
+
+	caps := GetCapabilities(dialect)
+	maxArgs, maxRows := b.maxArgs, b.maxRows
+	if maxArgs == 0 {
+		maxArgs = caps.MaxArgs
+	}
+	if maxRows == 0 {
+		maxRows = caps.MaxRows
+	}
+
+	size := vallen
+	if maxArgs > 0 {
+		// The arguments of ON DUPLICATE KEY UPDATE or ON CONFLICT DO UPDATE
+		// are shared by all the rows in each statement.
+		var fixed int
+		if b.upsert {
+			ab := NewArgsBuilder(dialect)
+			buf := getBuffer()
+			b.addUpsert(dialect, caps, buf, ab)
+			putBuffer(buf)
+			fixed = len(ab.Args())
+		}
+
+		if size = (maxArgs - fixed) / len(b.values[0]); size < 1 {
+			panic(buildErrorf("InsertBuilder", ErrInvalidStatement,
+				"a row has more than %d arguments", maxArgs))
+		}
+	}
+	if maxRows > 0 && size > maxRows {
+		size = maxRows
+	}
+	if size >= vallen {
+		return []*InsertBuilder{b}
+	}
+
+	batches := make([]*InsertBuilder, 0, (vallen+size-1)/size)
+	for start := 0; start < vallen; start += size {
+		end := start + size
+		if end > vallen {
+			end = vallen
+		}
+
+		batch := *b
+		batch.values = b.values[start:end:end]
+		batches = append(batches, &batch)
+	}
+	return batches
+}
+
+// ExecBatch is equal to b.ExecBatchContext(context.Background(), inTx...).
+func (b *InsertBuilder) ExecBatch(inTx ...bool) (rows int64, err error) {
+	return b.ExecBatchContext(context.Background(), inTx...)
+}
+
+// ExecBatchContext splits the inserted rows by Batches, executes the INSERT
+// statements in turn, and returns the total number of the affected rows.
+//
+// If inTx is true and there are more than one statement, they are executed
+// in a transaction, which requires that the executor has implemented
+// the method BeginTx, such as *sql.DB. Otherwise, the rows inserted by
+// the former statements are kept when the latter fails, and their number
+// is returned with the error.
+func (b *InsertBuilder) ExecBatchContext(ctx context.Context, inTx ...bool) (rows int64, err error) {
+	defer recoverBuildError(&err)
+	batches := b.Batches()
+	exec := b.executor
+	if len(inTx) > 0 && inTx[0] && len(batches) > 1 {
+		db, ok := exec.(txBeginner)
+		if !ok {
+			return 0, fmt.Errorf("sqlx: the executor %T does not support the transaction", exec)
+		}
+
+		var tx *sql.Tx
+		if tx, err = db.BeginTx(ctx, nil); err != nil {
+			return 0, err
+		}
+
+		defer func() {
+			if r := recover(); r != nil {
+				tx.Rollback()
+				panic(r)
+			} else if err != nil {
+				tx.Rollback()
+				rows = 0
+			} else if err = tx.Commit(); err != nil {
+				rows = 0
+			}
+		}()
+		exec = tx
+	}
+
+	var n int64
+	var query string
+	var args []interface{}
+	var result sql.Result
+	for _, batch := range batches {
+		if query, args, err = batch.BuildE(); err != nil {
+			return
+		}
+
+		if result, err = exec.ExecContext(ctx, query, args...); err != nil {
+			return
+		}
+
+		if n, err = result.RowsAffected(); err != nil {
+			return
+		}
+		rows += n
+	}
+
+	return
+}
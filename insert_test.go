@@ -15,8 +15,11 @@
 package sqlx
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
+	"testing"
 )
 
 func ExampleInsertBuilder() {
@@ -71,6 +74,28 @@ func ExampleInsertBuilder() {
 	// [v11 v12 v21 v22]
 }
 
+func ExampleInsertBuilder_oracle() {
+	insert1 := Insert().Into("table").Columns("c1", "c2").Values("v1", "v2")
+	insert2 := Insert().Into("table").Columns("c1", "c2").
+		Values("v11", "v12").
+		Values("v21", "v22")
+
+	sql1, args1 := insert1.SetDialect(Oracle).Build()
+	sql2, args2 := insert2.SetDialect(Oracle).Build()
+
+	fmt.Println(sql1)
+	fmt.Println(args1)
+
+	fmt.Println(sql2)
+	fmt.Println(args2)
+
+	// Output:
+	// INSERT INTO "table" ("c1", "c2") VALUES (:1, :2)
+	// [v1 v2]
+	// INSERT ALL INTO "table" ("c1", "c2") VALUES (:1, :2) INTO "table" ("c1", "c2") VALUES (:3, :4) SELECT 1 FROM DUAL
+	// [v11 v12 v21 v22]
+}
+
 func ExampleInsertBuilder_NamedValues() {
 	v1 := sql.Named("column1", "value1")
 	v2 := sql.Named("column2", "value2")
@@ -106,3 +131,220 @@ func ExampleInsertBuilder_Struct() {
 	// INSERT INTO `table` (`DefaultField`, `field`) VALUES (?, ?)
 	// [v1 ]
 }
+
+func ExampleInsertBuilder_Structs() {
+	type S struct {
+		ID      int     `sql:"id,omitempty"`
+		Name    string  `sql:"name"`
+		Comment *string `sql:"comment"`
+		Ignored string  `sql:"-"`
+	}
+
+	comment := "c"
+	ss := []*S{{ID: 1, Name: "a"}, nil, {Name: "b", Comment: &comment}}
+	insert := Insert().Into("table").Structs(ss)
+	sql1, args1 := insert.Build()
+
+	// The values are extracted by the preset columns.
+	insert = Insert().Into("table").Columns("name", "id").Structs(ss)
+	sql2, args2 := insert.Build()
+
+	// The field "id" with omitempty is omitted as it is zero in all the rows.
+	insert = Insert().Into("table").Structs([]S{{Name: "a"}, {Name: "b"}})
+	sql3, args3 := insert.Build()
+
+	_, _, err := Insert().Into("table").Columns("name", "age").Structs(ss).BuildE()
+
+	fmt.Println(sql1)
+	fmt.Println(args1)
+
+	fmt.Println(sql2)
+	fmt.Println(args2)
+
+	fmt.Println(sql3)
+	fmt.Println(args3)
+
+	fmt.Println(err)
+
+	// Output:
+	// INSERT INTO `table` (`id`, `name`, `comment`) VALUES (?, ?, ?), (?, ?, ?)
+	// [1 a <nil> 0 b c]
+	// INSERT INTO `table` (`name`, `id`) VALUES (?, ?), (?, ?)
+	// [a 1 b 0]
+	// INSERT INTO `table` (`name`, `comment`) VALUES (?, ?), (?, ?)
+	// [a <nil> b <nil>]
+	// InsertBuilder: the column 'age' is not a field of sqlx.S
+}
+
+func ExampleInsertBuilder_OnConflict() {
+	upsert := Insert().Into("table").Columns("id", "name", "count").Values(1, "a", 1).
+		OnConflict("id").DoUpdate(Inserted("name"), Add("count", 2))
+	ignore := Insert().Into("table").Columns("id", "name").Values(1, "a").
+		OnConflict("id").DoNothing()
+
+	sql1, args1 := upsert.SetDialect(MySQL).Build()
+	sql2, args2 := upsert.SetDialect(Postgres).Build()
+	sql3, args3 := ignore.SetDialect(MySQL).Build()
+	sql4, args4 := ignore.SetDialect(Sqlite3).Build()
+
+	fmt.Println(sql1)
+	fmt.Println(args1)
+
+	fmt.Println(sql2)
+	fmt.Println(args2)
+
+	fmt.Println(sql3)
+	fmt.Println(args3)
+
+	fmt.Println(sql4)
+	fmt.Println(args4)
+
+	// Output:
+	// INSERT INTO `table` (`id`, `name`, `count`) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE `name`=VALUES(`name`), `count`=`count`+?
+	// [1 a 1 2]
+	// INSERT INTO "table" ("id", "name", "count") VALUES ($1, $2, $3) ON CONFLICT ("id") DO UPDATE SET "name"=EXCLUDED."name", "count"="table"."count"+$4
+	// [1 a 1 2]
+	// INSERT INTO `table` (`id`, `name`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `id`=`id`
+	// [1 a]
+	// INSERT INTO "table" ("id", "name") VALUES (?, ?) ON CONFLICT ("id") DO NOTHING
+	// [1 a]
+}
+
+func ExampleInsertBuilder_Select() {
+	query := Selects("id", "name").From("users").Where(Less("id", 100))
+	insert := Insert().Into("archive").Columns("id", "name").Select(query).
+		OnConflict("id").DoUpdate(Inserted("name"), Set("count", 1))
+
+	sql1, args1 := insert.SetDialect(MySQL).Build()
+	sql2, args2 := insert.SetDialect(Postgres).Build()
+
+	fmt.Println(sql1)
+	fmt.Println(args1)
+
+	fmt.Println(sql2)
+	fmt.Println(args2)
+
+	// Output:
+	// INSERT INTO `archive` (`id`, `name`) SELECT `id`, `name` FROM `users` WHERE `id`<? ON DUPLICATE KEY UPDATE `name`=VALUES(`name`), `count`=?
+	// [100 1]
+	// INSERT INTO "archive" ("id", "name") SELECT "id", "name" FROM "users" WHERE "id"<$1 ON CONFLICT ("id") DO UPDATE SET "name"=EXCLUDED."name", "count"=$2
+	// [100 1]
+}
+
+func TestInsertBuilderSelectUpsertSqlite(t *testing.T) {
+	query := Selects("id", "name").From("users")
+	upsert := Insert().Into("archive").Columns("id", "name").Select(query).
+		OnConflict("id").DoUpdate(Inserted("name")).SetDialect(Sqlite3)
+
+	expected := `INSERT INTO "archive" ("id", "name") SELECT "id", "name" FROM "users" WHERE true ON CONFLICT ("id") DO UPDATE SET "name"=EXCLUDED."name"`
+	if sql := upsert.String(); sql != expected {
+		t.Errorf("expected '%s', got '%s'", expected, sql)
+	}
+	if sql := query.String(); sql != "SELECT `id`, `name` FROM `users`" {
+		t.Errorf("the query is modified: %s", sql)
+	}
+
+	query = Selects("id", "name").From("users").Where(Less("id", 100))
+	ignore := Insert().IgnoreInto("archive").Columns("id", "name").
+		Select(query).SetDialect(Sqlite3)
+	expected = `INSERT INTO "archive" ("id", "name") SELECT "id", "name" FROM "users" WHERE "id"<? ON CONFLICT DO NOTHING`
+	if sql := ignore.String(); sql != expected {
+		t.Errorf("expected '%s', got '%s'", expected, sql)
+	}
+
+	_, _, err := upsert.Select(Union(query, query)).BuildE()
+	if _, ok := err.(UnsupportedError); !ok {
+		t.Errorf("expect an UnsupportedError, but got %v", err)
+	}
+}
+
+func ExampleInsertBuilder_Batches() {
+	insert := Insert().Into("table").Columns("id", "name").SetDialect(Postgres).
+		Values(1, "a").Values(2, "b").Values(3, "c").Values(4, "d").Values(5, "e").
+		MaxArgs(4)
+
+	for _, batch := range insert.Batches() {
+		sql, args := batch.Build()
+		fmt.Println(sql)
+		fmt.Println(args)
+	}
+
+	// Output:
+	// INSERT INTO "table" ("id", "name") VALUES ($1, $2), ($3, $4)
+	// [1 a 2 b]
+	// INSERT INTO "table" ("id", "name") VALUES ($1, $2), ($3, $4)
+	// [3 c 4 d]
+	// INSERT INTO "table" ("id", "name") VALUES ($1, $2)
+	// [5 e]
+}
+
+type batchExecutor struct {
+	noopExecutor
+	sqls []string
+}
+
+func (e *batchExecutor) ExecContext(c context.Context, q string, a ...interface{}) (sql.Result, error) {
+	e.sqls = append(e.sqls, q)
+	return driver.RowsAffected(len(a) / 2), nil
+}
+
+func TestInsertBuilderExecBatch(t *testing.T) {
+	exec := new(batchExecutor)
+	insert := Insert().Into("table").Columns("id", "name").SetExecutor(exec)
+	for i := 0; i < 2500; i++ {
+		insert.Values(i, "name")
+	}
+
+	// SQL Server limits the placeholders to 2000 and the rows to 1000.
+	if rows, err := insert.SetDialect(MSSQL).ExecBatch(); err != nil {
+		t.Error(err)
+	} else if rows != 2500 {
+		t.Errorf("expect %d rows, but got %d", 2500, rows)
+	} else if len(exec.sqls) != 3 {
+		t.Errorf("expect %d statements, but got %d", 3, len(exec.sqls))
+	}
+
+	// The argument of DO UPDATE is shared by the rows in each statement.
+	insert.SetDialect(Postgres).MaxArgs(1001).OnConflict("id").DoUpdate(Set("name", "b"))
+	if batches := insert.Batches(); len(batches) != 5 {
+		t.Errorf("expect %d batches, but got %d", 5, len(batches))
+	} else if _, args := batches[0].Build(); len(args) != 1001 {
+		t.Errorf("expect %d arguments, but got %d", 1001, len(args))
+	}
+
+	if _, err := insert.ExecBatch(true); err == nil {
+		t.Errorf("expect an error about the transaction, but got nil")
+	}
+
+	empty := Insert().Into("table").Values().Values().SetExecutor(exec)
+	if _, err := empty.ExecBatch(); err == nil {
+		t.Error("expect the error ErrNoValues, but got nil")
+	} else if be, ok := err.(BuildError); !ok || be.Err != ErrNoValues {
+		t.Errorf("expect the error ErrNoValues, but got %v", err)
+	}
+}
+
+func TestInsertBuilderExecBatchRollback(t *testing.T) {
+	d := new(testDriver)
+	d.OnExec = func(string) {
+		if len(d.Execs) > 0 {
+			panic("driver error")
+		}
+	}
+
+	db := d.DB(MySQL)
+	defer db.Close()
+
+	func() {
+		defer func() {
+			if r := recover(); r != "driver error" {
+				t.Errorf("expect the panic 'driver error', but got %v", r)
+			}
+		}()
+		db.Insert().Into("table").Columns("id").Values(1).Values(2).MaxRows(1).ExecBatch(true)
+	}()
+
+	if fmt.Sprint(d.Txs) != "[rollback]" {
+		t.Errorf("expect the transaction to be rolled back, but got %v", d.Txs)
+	}
+}
@@ -0,0 +1,308 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Cursor is the position of the keyset pagination, which contains
+// the values of the ORDER BY columns of the boundary row.
+type Cursor struct {
+	// Values is the values of the ORDER BY columns in turn.
+	Values []interface{} `json:"v"`
+
+	// Backward reports whether to seek the rows before the position,
+	// that's, the previous page.
+	Backward bool `json:"b,omitempty"`
+}
+
+// IsZero reports whether the cursor is ZERO, that's, the first page.
+func (c Cursor) IsZero() bool { return len(c.Values) == 0 }
+
+// Encode encodes the cursor into the opaque token, which is the URL-safe
+// base64 encoding of the JSON.
+func (c Cursor) Encode() string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decodes the cursor from the token returned by Cursor.Encode.
+//
+// Notice: the integers and floats are decoded as int64 and float64,
+// and the others, such as time.Time, are decoded as the JSON types,
+// such as string.
+func DecodeCursor(token string) (c Cursor, err error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return c, fmt.Errorf("sqlx: invalid cursor: %v", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err = dec.Decode(&c); err != nil {
+		return c, fmt.Errorf("sqlx: invalid cursor: %v", err)
+	}
+
+	for i, v := range c.Values {
+		if n, ok := v.(json.Number); ok {
+			if c.Values[i], err = n.Int64(); err != nil {
+				if c.Values[i], err = n.Float64(); err != nil {
+					return c, fmt.Errorf("sqlx: invalid cursor: %v", err)
+				}
+			}
+		}
+	}
+
+	return
+}
+
+/// --------------------------------------------------------------------------
+
+// Seek sets the cursor of the keyset pagination, which is used instead of
+// OFFSET to seek the rows after or before the cursor in the order of ORDER BY.
+// The ZERO cursor means the first page. For example,
+//
+//	Selects("id", "created_at").From("posts").OrderByDesc("created_at").OrderByDesc("id").
+//		Limit(20).Seek(cursor)
+//	// SELECT ... FROM `posts` WHERE (`created_at`, `id`) < (?, ?)
+//	//   ORDER BY `created_at` DESC, `id` DESC LIMIT 20
+//
+// For the dialect not supporting RowValueCompare or the columns in the mixed
+// directions, the condition is expanded to "a<? OR (a=? AND b>?) ...".
+//
+// Notice: the ORDER BY columns must identify a row uniquely, such as ending
+// with the primary key, and must not be the expressions.
+// If the cursor is Backward, the ORDER BY directions are reversed in the
+// built SQL, so the rows must be reversed after scanning, which is done
+// by BindSeek.
+func (b *SelectBuilder) Seek(cursor Cursor) *SelectBuilder {
+	b = b.writable()
+	b.seek = &cursor
+	return b
+}
+
+// BindSeek is equal to b.BindSeekContext(context.Background(), slice).
+func (b *SelectBuilder) BindSeek(slice interface{}) (next, prev string, err error) {
+	return b.BindSeekContext(context.Background(), slice)
+}
+
+// BindSeekContext queries the page of the rows at the cursor set by Seek,
+// scans them into slice like BindRowsContext, and returns the tokens
+// of the next and previous pages, which are empty if there are no more rows.
+// The tokens are decoded by DecodeCursor to be passed to Seek.
+//
+// It requires LIMIT, and the ORDER BY columns must be the selected columns
+// or their aliases, which are scanned into the fields of the struct elements.
+func (b *SelectBuilder) BindSeekContext(ctx context.Context, slice interface{}) (
+	next, prev string, err error) {
+	if b.limit < 1 {
+		return "", "", buildErrorf("SelectBuilder", ErrInvalidStatement,
+			"keyset pagination requires LIMIT")
+	}
+
+	var cursor Cursor
+	if b.seek != nil {
+		cursor = *b.seek
+	}
+
+	// Query one more row to check whether there are more rows.
+	q := *b
+	q.limit, q.offset = b.limit+1, 0
+	if err = q.BindRowsContext(ctx, slice); err != nil {
+		return
+	}
+
+	rv := reflect.ValueOf(slice).Elem()
+	hasMore := rv.Len() > int(b.limit)
+	if hasMore {
+		rv.Set(rv.Slice(0, int(b.limit)))
+	}
+	if cursor.Backward {
+		reverseSlice(rv)
+	}
+
+	_len := rv.Len()
+	if _len == 0 {
+		return
+	}
+
+	var values []interface{}
+	if (!cursor.Backward && hasMore) || (cursor.Backward && !cursor.IsZero()) {
+		if values, err = b.getSeekValues(rv.Index(_len - 1)); err != nil {
+			return
+		}
+		next = Cursor{Values: values}.Encode()
+	}
+	if (!cursor.Backward && !cursor.IsZero()) || (cursor.Backward && hasMore) {
+		if values, err = b.getSeekValues(rv.Index(0)); err != nil {
+			return
+		}
+		prev = Cursor{Values: values, Backward: true}.Encode()
+	}
+
+	return
+}
+
+func reverseSlice(rv reflect.Value) {
+	swap := reflect.Swapper(rv.Interface())
+	for i, j := 0, rv.Len()-1; i < j; i, j = i+1, j-1 {
+		swap(i, j)
+	}
+}
+
+// getSeekValues returns the values of the ORDER BY columns of the row v.
+func (b *SelectBuilder) getSeekValues(v reflect.Value) ([]interface{}, error) {
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, errors.New("sqlx: keyset pagination requires the slice of structs")
+	}
+
+	info := getStructInfo(v.Type())
+	values := make([]interface{}, len(b.orderbys))
+	for i, ob := range b.orderbys {
+		index, ok := info.Indexes[b.getSeekColumnName(ob.Column)]
+		if !ok {
+			return nil, fmt.Errorf("sqlx: no field for the ORDER BY column '%s'", ob.Column)
+		}
+		values[i] = v.Field(index).Interface()
+	}
+	return values, nil
+}
+
+// getSeekColumnName returns the name of the scanned column of the ORDER BY
+// column, that's, the alias of the selected column, or the column name
+// without the table.
+func (b *SelectBuilder) getSeekColumnName(column string) string {
+	for _, c := range b.columns {
+		if c.Expr == nil && c.Column == column && c.Alias != "" {
+			return c.Alias
+		}
+	}
+
+	if index := strings.LastIndexByte(column, '.'); index > -1 {
+		return column[index+1:]
+	}
+	return column
+}
+
+/// --------------------------------------------------------------------------
+
+// getSeekOrderBys returns the ORDER BY columns, which are reversed
+// if seeking backward.
+func (b *SelectBuilder) getSeekOrderBys() []orderby {
+	if b.seek == nil || !b.seek.Backward {
+		return b.orderbys
+	}
+
+	orderbys := make([]orderby, len(b.orderbys))
+	for i, ob := range b.orderbys {
+		if ob.Order == Desc {
+			ob.Order = Asc
+		} else {
+			ob.Order = Desc
+		}
+		orderbys[i] = ob
+	}
+	return orderbys
+}
+
+// getSeekCondition returns the condition to seek the rows after the cursor
+// in the order of orderbys, or nil if there is no cursor.
+func (b *SelectBuilder) getSeekCondition(orderbys []orderby) Condition {
+	if b.seek == nil || b.seek.IsZero() {
+		return nil
+	} else if len(b.seek.Values) != len(orderbys) {
+		panic(buildErrorf("SelectBuilder", ErrInconsistentValues,
+			"the number of the cursor values is not equal to that of ORDER BY"))
+	}
+
+	for _, ob := range orderbys {
+		if ob.Expr != nil {
+			panic(buildErrorf("SelectBuilder", ErrInvalidStatement,
+				"keyset pagination does not support ORDER BY expression"))
+		}
+	}
+
+	return seekCondition{orderbys: orderbys, values: b.seek.Values}
+}
+
+type seekCondition struct {
+	orderbys []orderby
+	values   []interface{}
+}
+
+func (c seekCondition) op(order Order) string {
+	if order == Desc {
+		return "<"
+	}
+	return ">"
+}
+
+func (c seekCondition) Build(ab *ArgsBuilder) string {
+	sameOrder := true
+	for _, ob := range c.orderbys[1:] {
+		if (ob.Order == Desc) != (c.orderbys[0].Order == Desc) {
+			sameOrder = false
+			break
+		}
+	}
+
+	// (a, b) > (?, ?)
+	if len(c.orderbys) == 1 || (sameOrder && GetCapabilities(ab.Dialect).RowValueCompare) {
+		if len(c.orderbys) == 1 {
+			return ab.Quote(c.orderbys[0].Column) + c.op(c.orderbys[0].Order) +
+				ab.Add(c.values[0])
+		}
+
+		columns := make([]string, len(c.orderbys))
+		values := make([]string, len(c.orderbys))
+		for i, ob := range c.orderbys {
+			columns[i] = ab.Quote(ob.Column)
+			values[i] = ab.Add(c.values[i])
+		}
+		return fmt.Sprintf("(%s) %s (%s)", strings.Join(columns, ", "),
+			c.op(c.orderbys[0].Order), strings.Join(values, ", "))
+	}
+
+	// a>? OR (a=? AND b<?) OR (a=? AND b=? AND c>?)
+	ors := make([]Condition, len(c.orderbys))
+	for i, ob := range c.orderbys {
+		ands := make([]Condition, 0, i+1)
+		for j := 0; j < i; j++ {
+			ands = append(ands, Equal(c.orderbys[j].Column, c.values[j]))
+		}
+
+		op := newTwoCondition("%s"+c.op(ob.Order)+"%s", ob.Column, c.values[i])
+		if ands = append(ands, op); len(ands) == 1 {
+			ors[i] = op
+		} else {
+			ors[i] = And(ands...)
+		}
+	}
+	return Or(ors...).Build(ab)
+}
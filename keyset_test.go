@@ -0,0 +1,109 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleSelectBuilder_Seek() {
+	cursor, _ := DecodeCursor(Cursor{Values: []interface{}{"2020-01-01", 100}}.Encode())
+
+	s := Selects("id", "created_at").From("posts").Where(Equal("status", 1)).
+		OrderByDesc("created_at").OrderByDesc("id").Limit(20).Seek(cursor)
+	sql1, args1 := s.SetDialect(Postgres).Build()
+	sql2, args2 := s.SetDialect(MSSQL).Build()
+
+	mixed := Selects("id", "name").From("users").OrderBy("name").OrderByDesc("id").
+		Limit(20).Seek(Cursor{Values: []interface{}{"abc", 100}, Backward: true})
+	sql3, args3 := mixed.SetDialect(MySQL).Build()
+
+	fmt.Println(sql1)
+	fmt.Println(args1)
+
+	fmt.Println(sql2)
+	fmt.Println(args2)
+
+	fmt.Println(sql3)
+	fmt.Println(args3)
+
+	// Output:
+	// SELECT "id", "created_at" FROM "posts" WHERE ("status"=$1 AND ("created_at", "id") < ($2, $3)) ORDER BY "created_at" DESC, "id" DESC LIMIT 20
+	// [1 2020-01-01 100]
+	// SELECT [id], [created_at] FROM [posts] WHERE ([status]=@p1 AND ([created_at]<@p2 OR ([created_at]=@p3 AND [id]<@p4))) ORDER BY [created_at] DESC, [id] DESC OFFSET 0 ROWS FETCH NEXT 20 ROWS ONLY
+	// [1 2020-01-01 2020-01-01 100]
+	// SELECT `id`, `name` FROM `users` WHERE (`name`<? OR (`name`=? AND `id`>?)) ORDER BY `name` DESC, `id` ASC LIMIT 20
+	// [abc abc 100]
+}
+
+func TestSelectBuilderBindSeek(t *testing.T) {
+	db := newIDDriver([]int64{1, 2, 3}, []int64{3, 4}, []int64{2, 1}).DB(MySQL)
+	defer db.Close()
+
+	type Row struct {
+		ID int64 `sql:"id"`
+	}
+
+	seek := func(token string) (ids []int64, next, prev string) {
+		var err error
+		var cursor Cursor
+		if token != "" {
+			if cursor, err = DecodeCursor(token); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		var rows []Row
+		next, prev, err = db.Select("id").From("t").OrderBy("id").Limit(2).
+			Seek(cursor).BindSeek(&rows)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, row := range rows {
+			ids = append(ids, row.ID)
+		}
+		return
+	}
+
+	expect := func(page string, ids, expected []int64, next, prev string, hasNext, hasPrev bool) {
+		if fmt.Sprint(ids) != fmt.Sprint(expected) {
+			t.Errorf("%s: expect ids %v, but got %v", page, expected, ids)
+		}
+		if (next != "") != hasNext {
+			t.Errorf("%s: unexpected next cursor '%s'", page, next)
+		}
+		if (prev != "") != hasPrev {
+			t.Errorf("%s: unexpected prev cursor '%s'", page, prev)
+		}
+	}
+
+	// First Page: 1, 2
+	ids, next, prev := seek("")
+	expect("first page", ids, []int64{1, 2}, next, prev, true, false)
+
+	// Next Page: 3, 4
+	ids, next, prev = seek(next)
+	expect("second page", ids, []int64{3, 4}, next, prev, false, true)
+
+	// Previous Page: 1, 2 (queried in the reversed order)
+	ids, next, prev = seek(prev)
+	expect("previous page", ids, []int64{1, 2}, next, prev, true, false)
+
+	if c, _ := DecodeCursor(next); len(c.Values) != 1 || c.Values[0] != int64(2) || c.Backward {
+		t.Errorf("unexpected next cursor %+v", c)
+	}
+}
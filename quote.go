@@ -0,0 +1,257 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import "strings"
+
+// Raw is a raw sql expression, which is built verbatim without being quoted.
+//
+// It has implemented the interfaces Condition and Setter, so it can be used
+// as the WHERE condition and the SET statement. For example,
+//
+//	Select("*").From("table").Where(Raw("a + b > 10"))
+//	Update("table").Set(Raw("c = c * 2"))
+//
+// Notice: Raw must not contain the input from the untrusted user.
+type Raw string
+
+// Build implements the interfaces Condition and Setter.
+func (r Raw) Build(*ArgsBuilder) string { return string(r) }
+
+// QuoteKeywordsOnly returns a new Dialect based on d, which only quotes
+// the identifiers that are the reserved keywords or not the regular
+// identifiers, so that the generated sql stays readable. For example,
+//
+//	QuoteKeywordsOnly(MySQL).Quote("users.name")  // users.name
+//	QuoteKeywordsOnly(MySQL).Quote("users.order") // users.`order`
+//	QuoteKeywordsOnly(MySQL).Quote("users.a-b")   // users.`a-b`
+//
+// For PostgreSQL, the identifier containing the upper letters is quoted
+// to keep its case.
+func QuoteKeywordsOnly(d Dialect) Dialect {
+	quoted := d.Quote("a")
+	quoter := identQuoter{
+		Open:         quoted[0],
+		Close:        quoted[len(quoted)-1],
+		FoldLower:    d.Name() == pqDialect,
+		KeywordsOnly: true,
+	}
+	return keywordsDialect{Dialect: d, quoter: quoter}
+}
+
+type keywordsDialect struct {
+	Dialect
+	quoter identQuoter
+}
+
+func (d keywordsDialect) Quote(s string) string      { return d.quoter.Quote(s) }
+func (d keywordsDialect) Capabilities() Capabilities { return GetCapabilities(d.Dialect) }
+
+// identQuoter quotes the identifiers, such as "column", "table.column"
+// and "table.*".
+//
+// The function call, such as "SUM(column)", is not an identifier and is
+// quoted as a whole, so it must be built by Expression, such as Sum and Expr.
+type identQuoter struct {
+	Open  byte
+	Close byte
+
+	// FoldLower reports whether the unquoted identifiers are folded
+	// to the lower case, such as PostgreSQL.
+	FoldLower bool
+
+	// KeywordsOnly reports whether to quote only the reserved keywords
+	// and the identifiers that are not regular.
+	KeywordsOnly bool
+}
+
+func (q identQuoter) Quote(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "*" || isNumber(s) {
+		return s
+	}
+
+	parts := q.split(s)
+	for i, part := range parts {
+		if part != "*" || i != len(parts)-1 {
+			parts[i] = q.quoteIdent(part)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// quoteIdent quotes a single identifier, which escapes the embedded
+// quotation character by doubling it.
+func (q identQuoter) quoteIdent(s string) string {
+	if q.isQuoted(s) {
+		return s
+	} else if q.KeywordsOnly && isRegularIdent(s) && !isKeyword(s) &&
+		!(q.FoldLower && strings.ToLower(s) != s) {
+		return s
+	}
+
+	close := string(q.Close)
+	s = strings.Replace(s, close, close+close, -1)
+	return strings.Join([]string{string(q.Open), s, close}, "")
+}
+
+// isQuoted reports whether s is a quoted identifier, in which the embedded
+// quotation characters have been escaped.
+func (q identQuoter) isQuoted(s string) bool {
+	_len := len(s)
+	if _len < 2 || s[0] != q.Open || s[_len-1] != q.Close {
+		return false
+	}
+
+	for i := 1; i < _len-1; i++ {
+		if s[i] == q.Close {
+			if i+1 >= _len-1 || s[i+1] != q.Close {
+				return false
+			}
+			i++
+		}
+	}
+	return true
+}
+
+// skipQuoted returns the index after the quoted identifier starting at i,
+// or len(s) if it is not closed.
+func (q identQuoter) skipQuoted(s string, i int) int {
+	for i++; i < len(s); i++ {
+		if s[i] == q.Close {
+			if i+1 < len(s) && s[i+1] == q.Close {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return i
+}
+
+// split splits s by the dot outside the quoted identifiers.
+func (q identQuoter) split(s string) (parts []string) {
+	var start int
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == q.Open:
+			i = q.skipQuoted(s, i)
+			continue
+		case c == '.':
+			parts = append(parts, strings.TrimSpace(s[start:i]))
+			start = i + 1
+		}
+		i++
+	}
+	return append(parts, strings.TrimSpace(s[start:]))
+}
+
+// isNumber reports whether s is a decimal number, such as "1" or "1.5".
+func isNumber(s string) bool {
+	var digits, dots int
+	for i, _len := 0, len(s); i < _len; i++ {
+		switch b := s[i]; {
+		case '0' <= b && b <= '9':
+			digits++
+		case b == '.' && dots == 0 && digits > 0 && i+1 < _len:
+			dots++
+		default:
+			return false
+		}
+	}
+	return digits > 0
+}
+
+// isRegularIdent reports whether s is a regular identifier,
+// that's, "[A-Za-z_][A-Za-z0-9_]*".
+func isRegularIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, _len := 0, len(s); i < _len; i++ {
+		switch c := s[i]; {
+		case c == '_', 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z':
+		case '0' <= c && c <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func isKeyword(s string) bool {
+	_, ok := keywords[strings.ToUpper(s)]
+	return ok
+}
+
+// keywords is the union of the reserved keywords of the SQL standard
+// and the builtin dialects.
+var keywords = make(map[string]struct{}, len(keywordList))
+
+func init() {
+	for _, keyword := range keywordList {
+		keywords[keyword] = struct{}{}
+	}
+}
+
+var keywordList = []string{
+	"ACCESS", "ADD", "ALL", "ALTER", "ANALYSE", "ANALYZE", "AND", "ANY",
+	"ARRAY", "AS", "ASC", "ASYMMETRIC", "AUDIT", "AUTHORIZATION",
+	"AUTOINCREMENT", "BACKUP", "BEFORE", "BEGIN", "BETWEEN", "BIGINT",
+	"BINARY", "BLOB", "BOTH", "BREAK", "BROWSE", "BULK", "BY", "CALL",
+	"CASCADE", "CASE", "CAST", "CHANGE", "CHAR", "CHARACTER", "CHECK",
+	"CHECKPOINT", "CLOSE", "CLUSTER", "CLUSTERED", "COALESCE", "COLLATE",
+	"COLLATION", "COLUMN", "COMMENT", "COMMIT", "COMPRESS", "COMPUTE",
+	"CONCURRENTLY", "CONDITION", "CONNECT", "CONSTRAINT", "CONTAINS",
+	"CONTINUE", "CONVERT", "CREATE", "CROSS", "CURRENT", "CURRENT_DATE",
+	"CURRENT_ROLE", "CURRENT_TIME", "CURRENT_TIMESTAMP", "CURRENT_USER",
+	"CURSOR", "DATABASE", "DATABASES", "DATE", "DBCC", "DEALLOCATE", "DEC",
+	"DECIMAL", "DECLARE", "DEFAULT", "DEFERRABLE", "DELAYED", "DELETE",
+	"DENY", "DESC", "DESCRIBE", "DISTINCT", "DISTINCTROW", "DIV", "DO",
+	"DOUBLE", "DROP", "DUAL", "DUMP", "EACH", "ELSE", "ELSEIF", "END",
+	"ESCAPE", "EXCEPT", "EXCLUSIVE", "EXEC", "EXECUTE", "EXISTS", "EXIT",
+	"EXPLAIN", "FALSE", "FETCH", "FILE", "FLOAT", "FOR", "FORCE", "FOREIGN",
+	"FREEZE", "FROM", "FULL", "FULLTEXT", "FUNCTION", "GENERATED", "GET",
+	"GLOB", "GOTO", "GRANT", "GROUP", "GROUPS", "HAVING", "HOLDLOCK",
+	"IDENTIFIED", "IDENTITY", "IF", "IGNORE", "ILIKE", "IMMEDIATE", "IN",
+	"INCREMENT", "INDEX", "INITIAL", "INITIALLY", "INNER", "INOUT",
+	"INSERT", "INT", "INTEGER", "INTERSECT", "INTERVAL", "INTO", "IS",
+	"ISNULL", "ITERATE", "JOIN", "KEY", "KEYS", "KILL", "LATERAL",
+	"LEADING", "LEAVE", "LEFT", "LEVEL", "LIKE", "LIMIT", "LINEAR", "LINES",
+	"LOAD", "LOCALTIME", "LOCALTIMESTAMP", "LOCK", "LONG", "LOOP", "MATCH",
+	"MAXEXTENTS", "MERGE", "MINUS", "MOD", "MODE", "MODIFY", "NATURAL",
+	"NOAUDIT", "NOCHECK", "NOCOMPRESS", "NONCLUSTERED", "NOT", "NOTNULL",
+	"NOWAIT", "NULL", "NULLIF", "NUMBER", "NUMERIC", "OF", "OFFLINE",
+	"OFFSET", "ON", "ONLINE", "ONLY", "OPEN", "OPTION", "OPTIMIZE", "OR",
+	"ORDER", "OUT", "OUTER", "OUTFILE", "OVER", "OVERLAPS", "PARTITION",
+	"PCTFREE", "PERCENT", "PIVOT", "PLACING", "PLAN", "PRECISION",
+	"PRIMARY", "PRINT", "PRIOR", "PRIVILEGES", "PROC", "PROCEDURE",
+	"PUBLIC", "RAISE", "RAISERROR", "RANGE", "RAW", "READ", "READS", "REAL",
+	"RECURSIVE", "REFERENCES", "REGEXP", "RELEASE", "RENAME", "REPEAT",
+	"REPLACE", "REQUIRE", "RESOURCE", "RESTRICT", "RETURN", "RETURNING",
+	"REVOKE", "RIGHT", "RLIKE", "ROLLBACK", "ROW", "ROWID", "ROWNUM",
+	"ROWS", "RULE", "SAVE", "SCHEMA", "SCHEMAS", "SELECT", "SESSION",
+	"SESSION_USER", "SET", "SETUSER", "SHARE", "SHOW", "SHUTDOWN",
+	"SIMILAR", "SIZE", "SMALLINT", "SOME", "SPATIAL", "SQL", "START",
+	"STATISTICS", "SUCCESSFUL", "SYMMETRIC", "SYNONYM", "SYSDATE",
+	"SYSTEM_USER", "TABLE", "TABLESAMPLE", "TEMPORARY", "TEXTSIZE", "THEN",
+	"TIME", "TIMESTAMP", "TO", "TOP", "TRAILING", "TRAN", "TRANSACTION",
+	"TRIGGER", "TRUE", "TRUNCATE", "UID", "UNION", "UNIQUE", "UNLOCK",
+	"UNPIVOT", "UNSIGNED", "UPDATE", "USAGE", "USE", "USER", "USING",
+	"VALIDATE", "VALUES", "VARCHAR", "VARCHAR2", "VARIADIC", "VARYING",
+	"VERBOSE", "VIEW", "WAITFOR", "WHEN", "WHENEVER", "WHERE", "WHILE",
+	"WINDOW", "WITH", "WRITE", "XOR", "ZEROFILL",
+}
@@ -0,0 +1,112 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		Dialect  Dialect
+		Input    string
+		Expected string
+	}{
+		{MySQL, "*", "*"},
+		{MySQL, "123", "123"},
+		{MySQL, " name ", "`name`"},
+		{MySQL, "t.*", "`t`.*"},
+		{MySQL, "t.name", "`t`.`name`"},
+		{MySQL, "`t`.name", "`t`.`name`"},
+		{MySQL, "a`b", "`a``b`"},
+		{MySQL, "`a``b`", "`a``b`"},
+		{MySQL, "`a`b`", "```a``b```"},
+		{MySQL, "`a.b`.c", "`a.b`.`c`"},
+		{MySQL, "a b", "`a b`"},
+		{MySQL, "id`; DROP TABLE t; --", "`id``; DROP TABLE t; --`"},
+		{MySQL, "1.5", "1.5"},
+		{MySQL, "1.2.3", "`1`.`2`.`3`"},
+		{MySQL, "1.", "`1`.``"},
+		{MySQL, "COUNT(*)", "`COUNT(*)`"},
+		{MySQL, "SLEEP(10)", "`SLEEP(10)`"},
+		{MySQL, "SUM(t.a)", "`SUM(t`.`a)`"},
+		{MySQL, "a)", "`a)`"},
+		{Postgres, `a"b`, `"a""b"`},
+		{Postgres, `"a"b"`, `"""a""b"""`},
+		{MSSQL, "a]b", "[a]]b]"},
+		{MSSQL, "[a]].b]", "[a]].b]"},
+		{MSSQL, "[t].[a]", "[t].[a]"},
+	}
+
+	for _, test := range tests {
+		if s := test.Dialect.Quote(test.Input); s != test.Expected {
+			t.Errorf("%s: %s: expected '%s', got '%s'", test.Dialect.Name(),
+				test.Input, test.Expected, s)
+		}
+	}
+}
+
+func TestQuoteKeywordsOnly(t *testing.T) {
+	mysql := QuoteKeywordsOnly(MySQL)
+	postgres := QuoteKeywordsOnly(Postgres)
+
+	tests := []struct {
+		Dialect  Dialect
+		Input    string
+		Expected string
+	}{
+		{mysql, "users.name", "users.name"},
+		{mysql, "users.order", "users.`order`"},
+		{mysql, "users.a-b", "users.`a-b`"},
+		{mysql, "users.a`b", "users.`a``b`"},
+		{mysql, "SUM(Amount)", "`SUM(Amount)`"},
+		{postgres, "Amount", `"Amount"`},
+		{postgres, "amount", `amount`},
+		{postgres, "select", `"select"`},
+	}
+
+	for _, test := range tests {
+		if s := test.Dialect.Quote(test.Input); s != test.Expected {
+			t.Errorf("%s: %s: expected '%s', got '%s'", test.Dialect.Name(),
+				test.Input, test.Expected, s)
+		}
+	}
+
+	if c := GetCapabilities(postgres); c.Upsert != UpsertOnConflict {
+		t.Errorf("expected the capabilities of postgres, got %+v", c)
+	}
+}
+
+func ExampleRaw() {
+	sel := Select("id").SelectRaw("COUNT(DISTINCT a, b)", "total").From("table").
+		Where(Raw("a + b > 10")).GroupBy("id").OrderByRaw("FIELD(id, 3, 1, 2)")
+	update := Update().Table("table").Set(Raw("c = c * 2")).Where(Equal("id", 1))
+
+	sql1, args1 := sel.Build()
+	sql2, args2 := update.Build()
+
+	fmt.Println(sql1)
+	fmt.Println(args1)
+
+	fmt.Println(sql2)
+	fmt.Println(args2)
+
+	// Output:
+	// SELECT `id`, COUNT(DISTINCT a, b) AS `total` FROM `table` WHERE a + b > 10 GROUP BY `id` ORDER BY FIELD(id, 3, 1, 2)
+	// []
+	// UPDATE `table` SET c = c * 2 WHERE `id`=?
+	// [1]
+}
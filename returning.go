@@ -0,0 +1,93 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import (
+	"bytes"
+	"context"
+	"strings"
+)
+
+// returningBuilder is used to query the rows returned by INSERT, UPDATE
+// and DELETE with the RETURNING clause.
+type returningBuilder struct {
+	executor Executor
+	columns  []string
+}
+
+// Query executes the query built by the builder, or returns err
+// if failing to build it.
+func (r returningBuilder) Query(ctx context.Context, query string,
+	args []interface{}, err error) (Rows, error) {
+	if err != nil {
+		return Rows{SelectBuilder: Selects(r.columns...)}, err
+	}
+
+	rows, err := r.executor.QueryContext(ctx, query, args...)
+	return Rows{SelectBuilder: Selects(r.columns...), Rows: rows}, err
+}
+
+// QueryRow is the same as Query, but queries one row.
+func (r returningBuilder) QueryRow(ctx context.Context, query string,
+	args []interface{}, err error) Row {
+	if err != nil {
+		return Row{SelectBuilder: Selects(r.columns...), err: err}
+	}
+	return Row{SelectBuilder: Selects(r.columns...),
+		Row: r.executor.QueryRowContext(ctx, query, args...)}
+}
+
+// addReturning appends the RETURNING clause, such as PostgreSQL and SQLite.
+func addReturning(buf *bytes.Buffer, dialect Dialect, columns []string) {
+	if len(columns) == 0 {
+		return
+	}
+
+	switch GetCapabilities(dialect).Returning {
+	case ReturningClause:
+		buf.WriteString(" RETURNING ")
+		for i, column := range columns {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(dialect.Quote(column))
+		}
+	case ReturningOutput:
+	default:
+		panic(unsupported(dialect, "RETURNING"))
+	}
+}
+
+// addOutput appends the OUTPUT clause, such as SQL Server, whose prefix
+// is INSERTED or DELETED.
+func addOutput(buf *bytes.Buffer, dialect Dialect, prefix string, columns []string) {
+	if len(columns) == 0 || GetCapabilities(dialect).Returning != ReturningOutput {
+		return
+	}
+
+	buf.WriteString(" OUTPUT ")
+	for i, column := range columns {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+
+		if index := strings.LastIndexByte(column, '.'); index > -1 {
+			column = column[index+1:]
+		}
+		buf.WriteString(prefix)
+		buf.WriteByte('.')
+		buf.WriteString(dialect.Quote(column))
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleInsertBuilder_Returning() {
+	insert := Insert().Into("table").Columns("name").Values("a").Returning("id", "created_at")
+	update := Update().Table("table").Set(Assign("name", "b")).Where(Equal("id", 1)).Returning("id")
+	del := Delete().From("table").Where(Equal("id", 1)).Returning("id", "name")
+
+	for _, dialect := range []Dialect{Postgres, MSSQL} {
+		sql1, args1 := insert.SetDialect(dialect).Build()
+		sql2, args2 := update.SetDialect(dialect).Build()
+		sql3, args3 := del.SetDialect(dialect).Build()
+
+		fmt.Println(sql1)
+		fmt.Println(args1)
+
+		fmt.Println(sql2)
+		fmt.Println(args2)
+
+		fmt.Println(sql3)
+		fmt.Println(args3)
+	}
+
+	// Output:
+	// INSERT INTO "table" ("name") VALUES ($1) RETURNING "id", "created_at"
+	// [a]
+	// UPDATE "table" SET "name"=$1 WHERE "id"=$2 RETURNING "id"
+	// [b 1]
+	// DELETE FROM "table" WHERE "id"=$1 RETURNING "id", "name"
+	// [1]
+	// INSERT INTO [table] ([name]) OUTPUT INSERTED.[id], INSERTED.[created_at] VALUES (@p1)
+	// [a]
+	// UPDATE [table] SET [name]=@p1 OUTPUT INSERTED.[id] WHERE [id]=@p2
+	// [b 1]
+	// DELETE FROM [table] OUTPUT DELETED.[id], DELETED.[name] WHERE [id]=@p1
+	// [1]
+}
+
+func TestReturning(t *testing.T) {
+	insert := Insert().Into("table").Columns("name").Values("a").Returning("id", "name")
+	expectUnsupported(t, "RETURNING", insert.SetDialect(MySQL).String)
+
+	rows, _ := insert.SetDialect(Postgres).SetExecutor(noopExecutor{}).Query()
+	if columns := rows.SelectedColumns(); len(columns) != 2 ||
+		columns[0] != "id" || columns[1] != "name" {
+		t.Errorf("unexpected returned columns %v", columns)
+	}
+
+	del := Delete().From("table", "A").JoinLeft("table2", "B", On("A.id", "B.id")).
+		Where(IsNull("B.id")).Returning("A.id").SetDialect(MSSQL)
+	expected := `DELETE [A] OUTPUT DELETED.[id] FROM [table] AS [A] LEFT JOIN [table2] AS [B] ON [A].[id]=[B].[id] WHERE [B].[id] IS NULL`
+	if s := del.String(); s != expected {
+		t.Errorf("expected '%s', got '%s'", expected, s)
+	}
+}
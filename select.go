@@ -49,16 +49,71 @@ func NewSelectBuilder(column string, alias ...string) *SelectBuilder {
 type sqlTable struct {
 	Table string
 	Alias string
+
+	// Query is the derived table, that's, "(query) AS alias",
+	// which is used instead of Table if not nil.
+	Query Builder
+}
+
+// IsNamed reports whether name is the name or the alias of the table.
+func (t sqlTable) IsNamed(name string) bool {
+	return (t.Table != "" && name == t.Table) || (t.Alias != "" && name == t.Alias)
+}
+
+func (t sqlTable) Build(buf *bytes.Buffer, ab *ArgsBuilder) {
+	if t.Query == nil {
+		buf.WriteString(ab.Quote(t.Table))
+	} else {
+		buf.WriteByte('(')
+		buf.WriteString(buildNested(ab, t.Query))
+		buf.WriteByte(')')
+	}
+
+	if t.Alias != "" {
+		buf.WriteString(tableAliasKeyword(ab.Dialect))
+		buf.WriteString(ab.Quote(t.Alias))
+	}
 }
 
 type selectedColumn struct {
 	Column string
 	Alias  string
+	Expr   Expression
+	Window *Window
+}
+
+func (c selectedColumn) Build(ab *ArgsBuilder) string {
+	var column string
+	if c.Expr == nil {
+		column = ab.Quote(c.Column)
+	} else {
+		column = c.Expr.Build(ab)
+	}
+
+	if c.Window == nil {
+		return column
+	}
+
+	buf := getBuffer()
+	buf.WriteString(column)
+	buf.WriteString(" OVER ")
+	c.Window.build(buf, ab, true)
+	s := buf.String()
+	putBuffer(buf)
+	return s
 }
 
 type orderby struct {
 	Column string
 	Order  Order
+	Expr   Expression
+}
+
+func (o orderby) Build(ab *ArgsBuilder) string {
+	if o.Expr == nil {
+		return ab.Quote(o.Column)
+	}
+	return o.Expr.Build(ab)
 }
 
 // Order represents the order used by ORDER BY.
@@ -79,35 +134,70 @@ type JoinOn struct {
 // On returns a JoinOn instance.
 func On(left, right string) JoinOn { return JoinOn{Left: left, Right: right} }
 
+// Build implements the interface Condition, which returns "left=right".
+func (on JoinOn) Build(ab *ArgsBuilder) string {
+	return ab.Quote(on.Left) + "=" + ab.Quote(on.Right)
+}
+
+// joinOns converts the JoinOns to the Conditions.
+func joinOns(ons []JoinOn) []Condition {
+	if len(ons) == 0 {
+		return nil
+	}
+
+	conds := make([]Condition, len(ons))
+	for i, on := range ons {
+		conds[i] = on
+	}
+	return conds
+}
+
 type joinTable struct {
 	Type  string
 	Table string
 	Alias string
-	Ons   []JoinOn
+	Query Builder
+	Ons   []Condition
+	Using []string
 }
 
-func (jt joinTable) Build(buf *bytes.Buffer, dialect Dialect) {
+func (jt joinTable) Build(buf *bytes.Buffer, ab *ArgsBuilder) {
+	dialect := ab.Dialect
+	caps := GetCapabilities(dialect)
+	if !caps.FullJoin && strings.HasPrefix(jt.Type, "FULL") {
+		panic(unsupported(dialect, "FULL JOIN"))
+	} else if !caps.RightJoin && strings.HasPrefix(jt.Type, "RIGHT") {
+		panic(unsupported(dialect, "RIGHT JOIN"))
+	} else if !caps.NaturalJoin && strings.HasPrefix(jt.Type, "NATURAL") {
+		panic(unsupported(dialect, "NATURAL JOIN"))
+	} else if !caps.JoinUsing && len(jt.Using) > 0 {
+		panic(unsupported(dialect, "JOIN USING"))
+	}
+
 	if jt.Type != "" {
 		buf.WriteByte(' ')
 		buf.WriteString(jt.Type)
 	}
 
 	buf.WriteString(" JOIN ")
-	buf.WriteString(dialect.Quote(jt.Table))
-	if jt.Alias != "" {
-		buf.WriteString(" AS ")
-		buf.WriteString(dialect.Quote(jt.Alias))
-	}
+	sqlTable{Table: jt.Table, Alias: jt.Alias, Query: jt.Query}.Build(buf, ab)
 
-	if len(jt.Ons) > 0 {
+	if len(jt.Using) > 0 {
+		buf.WriteString(" USING (")
+		for i, column := range jt.Using {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(dialect.Quote(column))
+		}
+		buf.WriteByte(')')
+	} else if len(jt.Ons) > 0 {
 		buf.WriteString(" ON ")
 		for i, on := range jt.Ons {
 			if i > 0 {
 				buf.WriteString(" AND ")
 			}
-			buf.WriteString(dialect.Quote(on.Left))
-			buf.WriteByte('=')
-			buf.WriteString(dialect.Quote(on.Right))
+			buf.WriteString(on.Build(ab))
 		}
 	}
 }
@@ -119,20 +209,72 @@ type SelectBuilder struct {
 	intercept Interceptor
 	executor  Executor
 	dialect   Dialect
+	withs     commonTables
 	distinct  bool
 	tables    []sqlTable
 	columns   []selectedColumn
 	joins     []joinTable
 	wheres    []Condition
-	groupbys  []string
-	havings   []string
+	groupbys  []Expression
+	havings   []Condition
 	orderbys  []orderby
 	limit     int64
 	offset    int64
+
+	windows []namedWindow
+	seek    *Cursor
+
+	lock     string
+	lockOf   []string
+	lockWait string
+
+	// err is the error occurred when chaining the builder,
+	// which is reported when building.
+	err error
+
+	immutable bool
+}
+
+// setError records the first error occurred when chaining the builder.
+func (b *SelectBuilder) setError(err error) *SelectBuilder {
+	if b.err == nil {
+		b.err = err
+	}
+	return b
+}
+
+// With appends the common table expression "WITH name (columns...) AS (query)",
+// then From and Join can refer to the name as the table. For example,
+//
+//	active := Selects("id", "name").From("users").Where(Equal("status", 1))
+//	Selects("id", "name").With("active", active).From("active")
+//	// WITH `active` AS (SELECT `id`, `name` FROM `users` WHERE `status`=?)
+//	// SELECT `id`, `name` FROM `active`
+//
+// The arguments of query are placed before those of the SELECT statement.
+func (b *SelectBuilder) With(name string, query Builder, columns ...string) *SelectBuilder {
+	b = b.writable()
+	if err := b.withs.Add(false, name, query, columns); err != nil {
+		return b.setError(err)
+	}
+	return b
+}
+
+// WithRecursive is the same as With, but the query may refer to the name
+// itself, which is built as "WITH RECURSIVE ...", or "WITH ..." for
+// the dialect not requiring the keyword RECURSIVE, such as SQL Server
+// and Oracle, which also require the columns.
+func (b *SelectBuilder) WithRecursive(name string, query Builder, columns ...string) *SelectBuilder {
+	b = b.writable()
+	if err := b.withs.Add(true, name, query, columns); err != nil {
+		return b.setError(err)
+	}
+	return b
 }
 
 // Distinct marks SELECT as DISTINCT.
 func (b *SelectBuilder) Distinct() *SelectBuilder {
+	b = b.writable()
 	b.distinct = true
 	return b
 }
@@ -152,17 +294,92 @@ func (b *SelectBuilder) getAlias(column string, alias []string) string {
 
 // Select appends the selected column in SELECT.
 func (b *SelectBuilder) Select(column string, alias ...string) *SelectBuilder {
+	b = b.writable()
 	if column != "" {
-		b.columns = append(b.columns, selectedColumn{column, b.getAlias(column, alias)})
+		b.columns = append(b.columns, selectedColumn{Column: column, Alias: b.getAlias(column, alias)})
 	}
 
 	return b
 }
 
+// SelectRaw appends the raw expression as the selected column in SELECT,
+// which is not quoted, such as "COUNT(DISTINCT a, b)" or "a + b".
+//
+// Notice: expr must not contain the input from the untrusted user.
+func (b *SelectBuilder) SelectRaw(expr string, alias ...string) *SelectBuilder {
+	b = b.writable()
+	if expr != "" {
+		var calias string
+		if len(alias) != 0 {
+			calias = alias[0]
+		}
+		b.columns = append(b.columns, selectedColumn{Column: expr, Alias: calias, Expr: Raw(expr)})
+	}
+	return b
+}
+
+// SelectExpr appends the expression as the selected column in SELECT,
+// whose arguments are placed in the order that they appear. For example,
+//
+//	SelectExpr(Expr("COALESCE(?, ?)", Ident("nickname"), "anonymous"), "name")
+//	SelectExpr(Expr("? * ?", Ident("price"), Ident("qty")), "total")
+//
+// Notice: the returned column of SelectedColumns is the alias.
+func (b *SelectBuilder) SelectExpr(expr Expression, alias ...string) *SelectBuilder {
+	b = b.writable()
+	if expr != nil {
+		var calias string
+		if len(alias) != 0 {
+			calias = alias[0]
+		}
+		b.columns = append(b.columns, selectedColumn{Alias: calias, Expr: expr})
+	}
+	return b
+}
+
+// SelectOver appends the window function call "function OVER window"
+// as the selected column, whose arguments are placed in the order that
+// they appear. For example,
+//
+//	SelectOver(Raw("ROW_NUMBER()"), NewWindow().PartitionBy("user_id").OrderByDesc("created_at"), "rn")
+//	// ROW_NUMBER() OVER (PARTITION BY `user_id` ORDER BY `created_at` DESC) AS `rn`
+//
+//	SelectOver(Sum("amount"), NewWindow("w"), "total").Window("w", NewWindow().PartitionBy("user_id"))
+//	// SUM(`amount`) OVER `w` AS `total` ... WINDOW `w` AS (PARTITION BY `user_id`)
+//
+// Notice: the returned column of SelectedColumns is the alias.
+func (b *SelectBuilder) SelectOver(function Expression, window *Window, alias ...string) *SelectBuilder {
+	b = b.writable()
+	if function == nil || window == nil {
+		return b.setError(buildErrorf("SelectBuilder", ErrInvalidStatement,
+			"the window function and the window must not be nil"))
+	}
+
+	var calias string
+	if len(alias) != 0 {
+		calias = alias[0]
+	}
+	b.columns = append(b.columns, selectedColumn{Alias: calias, Expr: function, Window: window})
+	return b
+}
+
+// Window appends the named window definition "WINDOW name AS (window)",
+// which is placed after HAVING and may be referred by SelectOver.
+// But it is not supported by SQL Server before 2022 and Oracle.
+func (b *SelectBuilder) Window(name string, window *Window) *SelectBuilder {
+	b = b.writable()
+	if name == "" || window == nil {
+		return b.setError(buildErrorf("SelectBuilder", ErrInvalidStatement,
+			"the named window has no name or specification"))
+	}
+	b.windows = append(b.windows, namedWindow{Name: name, Window: window})
+	return b
+}
+
 // Selects is equal to Select(columns[0]).Select(columns[1])...
 func (b *SelectBuilder) Selects(columns ...string) *SelectBuilder {
 	for _, c := range columns {
-		b.Select(c)
+		b = b.Select(c)
 	}
 	return b
 }
@@ -187,11 +404,14 @@ func (b *SelectBuilder) SelectStruct(s interface{}, table ...string) *SelectBuil
 
 		v = v.Elem()
 		if v.Kind() != reflect.Struct {
-			panic("not a pointer to struct")
+			b = b.writable()
+			return b.setError(buildErrorf("SelectBuilder", ErrInvalidStatement,
+				"%T is not a pointer to struct", s))
 		}
 	case reflect.Struct:
 	default:
-		panic("not a struct")
+		b = b.writable()
+		return b.setError(buildErrorf("SelectBuilder", ErrInvalidStatement, "%T is not a struct", s))
 	}
 
 	var ftable string
@@ -199,28 +419,14 @@ func (b *SelectBuilder) SelectStruct(s interface{}, table ...string) *SelectBuil
 		ftable = table[0]
 	}
 
-	vt := v.Type()
-	for i, _len := 0, v.NumField(); i < _len; i++ {
-		vft := vt.Field(i)
-		name := vft.Name
-
-		tag := vft.Tag.Get("sql")
-		if index := strings.IndexByte(tag, ','); index > -1 {
-			tag = strings.TrimSpace(tag[:index])
-		}
-
-		if tag == "-" {
-			continue
-		} else if tag != "" {
-			name = tag
-		}
-
+	for _, field := range getStructInfo(v.Type()).Fields {
+		name := field.Name
 		if ftable != "" {
 			name = fmt.Sprintf("%s.%s", ftable, name)
-		} else if table := vft.Tag.Get("table"); table != "" {
-			name = fmt.Sprintf("%s.%s", table, name)
+		} else if field.Table != "" {
+			name = fmt.Sprintf("%s.%s", field.Table, name)
 		}
-		b.Select(name)
+		b = b.Select(name)
 	}
 
 	return b
@@ -243,78 +449,220 @@ func (b *SelectBuilder) SelectedColumns() []string {
 
 // From sets table name in SELECT.
 func (b *SelectBuilder) From(table string, alias ...string) *SelectBuilder {
-	b.tables = append(b.tables, sqlTable{table, b.getAlias(table, alias)})
+	b = b.writable()
+	b.tables = append(b.tables, sqlTable{Table: table, Alias: b.getAlias(table, alias)})
+	return b
+}
+
+// FromSelect appends the derived table "(query) AS alias" in SELECT,
+// which alias is required. For example,
+//
+//	latest := Select("user_id").SelectExpr(Max("id"), "id").From("orders").GroupBy("user_id")
+//	Selects("o.*").FromSelect(latest, "l").Join("orders", "o", On("o.id", "l.id"))
+//	// SELECT `o`.* FROM (SELECT `user_id`, MAX(`id`) AS `id` FROM `orders` GROUP BY `user_id`) AS `l`
+//	//   JOIN `orders` AS `o` ON `o`.`id`=`l`.`id`
+//
+// If query implements the interface NestedBuilder, such as SelectBuilder,
+// it is built with the dialect of the SELECT statement and its arguments
+// are numbered in the order that they appear in the SELECT statement.
+func (b *SelectBuilder) FromSelect(query Builder, alias string) *SelectBuilder {
+	b = b.writable()
+	if err := checkDerivedTable(query, alias); err != nil {
+		return b.setError(err)
+	}
+	b.tables = append(b.tables, sqlTable{Alias: alias, Query: query})
+	return b
+}
+
+func checkDerivedTable(query Builder, alias string) error {
+	if query == nil {
+		return buildErrorf("SelectBuilder", ErrInvalidStatement,
+			"the derived table must not be nil")
+	} else if alias == "" {
+		return buildErrorf("SelectBuilder", ErrInvalidStatement,
+			"the derived table has no alias")
+	}
+	return nil
+}
+
+// JoinSelect appends the "JOIN (query) AS alias ON on..." statement,
+// which alias is required.
+func (b *SelectBuilder) JoinSelect(query Builder, alias string, ons ...Condition) *SelectBuilder {
+	return b.joinSelect("", query, alias, ons)
+}
+
+// JoinLeftSelect appends the "LEFT JOIN (query) AS alias ON on..." statement,
+// which alias is required.
+func (b *SelectBuilder) JoinLeftSelect(query Builder, alias string, ons ...Condition) *SelectBuilder {
+	return b.joinSelect("LEFT", query, alias, ons)
+}
+
+func (b *SelectBuilder) joinSelect(cmd string, query Builder, alias string, ons []Condition) *SelectBuilder {
+	b = b.writable()
+	if err := checkDerivedTable(query, alias); err != nil {
+		return b.setError(err)
+	}
+	b.joins = append(b.joins, joinTable{Type: cmd, Alias: alias, Query: query, Ons: ons})
 	return b
 }
 
-// Join appends the "JOIN table ON on..." statement.
+// Join appends the "JOIN table ON on..." statement, such as On("t1.id", "t2.tid").
+//
+// For the general conditions, such as Equal("t2.type", 1), use JoinCond instead.
 func (b *SelectBuilder) Join(table, alias string, ons ...JoinOn) *SelectBuilder {
-	return b.joinTable("", table, alias, ons...)
+	return b.joinTable("", table, alias, joinOns(ons))
+}
+
+// JoinCond appends the "JOIN table ON cond..." statement, which conds may be
+// any Condition, such as On("t1.id", "t2.tid") and Equal("t2.type", 1),
+// and are joined by AND.
+func (b *SelectBuilder) JoinCond(table, alias string, conds ...Condition) *SelectBuilder {
+	return b.joinTable("", table, alias, conds)
 }
 
 // JoinLeft appends the "LEFT JOIN table ON on..." statement.
 func (b *SelectBuilder) JoinLeft(table, alias string, ons ...JoinOn) *SelectBuilder {
-	return b.joinTable("LEFT", table, alias, ons...)
+	return b.joinTable("LEFT", table, alias, joinOns(ons))
+}
+
+// JoinLeftCond appends the "LEFT JOIN table ON cond..." statement like JoinCond.
+func (b *SelectBuilder) JoinLeftCond(table, alias string, conds ...Condition) *SelectBuilder {
+	return b.joinTable("LEFT", table, alias, conds)
 }
 
 // JoinLeftOuter appends the "LEFT OUTER JOIN table ON on..." statement.
 func (b *SelectBuilder) JoinLeftOuter(table, alias string, ons ...JoinOn) *SelectBuilder {
-	return b.joinTable("LEFT OUTER", table, alias, ons...)
+	return b.joinTable("LEFT OUTER", table, alias, joinOns(ons))
+}
+
+// JoinLeftOuterCond appends the "LEFT OUTER JOIN table ON cond..." statement like JoinCond.
+func (b *SelectBuilder) JoinLeftOuterCond(table, alias string, conds ...Condition) *SelectBuilder {
+	return b.joinTable("LEFT OUTER", table, alias, conds)
 }
 
 // JoinRight appends the "RIGHT JOIN table ON on..." statement.
 func (b *SelectBuilder) JoinRight(table, alias string, ons ...JoinOn) *SelectBuilder {
-	return b.joinTable("RIGHT", table, alias, ons...)
+	return b.joinTable("RIGHT", table, alias, joinOns(ons))
+}
+
+// JoinRightCond appends the "RIGHT JOIN table ON cond..." statement like JoinCond.
+func (b *SelectBuilder) JoinRightCond(table, alias string, conds ...Condition) *SelectBuilder {
+	return b.joinTable("RIGHT", table, alias, conds)
 }
 
 // JoinRightOuter appends the "RIGHT OUTER JOIN table ON on..." statement.
 func (b *SelectBuilder) JoinRightOuter(table, alias string, ons ...JoinOn) *SelectBuilder {
-	return b.joinTable("RIGHT OUTER", table, alias, ons...)
+	return b.joinTable("RIGHT OUTER", table, alias, joinOns(ons))
+}
+
+// JoinRightOuterCond appends the "RIGHT OUTER JOIN table ON cond..." statement like JoinCond.
+func (b *SelectBuilder) JoinRightOuterCond(table, alias string, conds ...Condition) *SelectBuilder {
+	return b.joinTable("RIGHT OUTER", table, alias, conds)
 }
 
 // JoinFull appends the "FULL JOIN table ON on..." statement.
 func (b *SelectBuilder) JoinFull(table, alias string, ons ...JoinOn) *SelectBuilder {
-	return b.joinTable("FULL", table, alias, ons...)
+	return b.joinTable("FULL", table, alias, joinOns(ons))
+}
+
+// JoinFullCond appends the "FULL JOIN table ON cond..." statement like JoinCond.
+func (b *SelectBuilder) JoinFullCond(table, alias string, conds ...Condition) *SelectBuilder {
+	return b.joinTable("FULL", table, alias, conds)
 }
 
 // JoinFullOuter appends the "FULL OUTER JOIN table ON on..." statement.
 func (b *SelectBuilder) JoinFullOuter(table, alias string, ons ...JoinOn) *SelectBuilder {
-	return b.joinTable("FULL OUTER", table, alias, ons...)
+	return b.joinTable("FULL OUTER", table, alias, joinOns(ons))
+}
+
+// JoinFullOuterCond appends the "FULL OUTER JOIN table ON cond..." statement like JoinCond.
+func (b *SelectBuilder) JoinFullOuterCond(table, alias string, conds ...Condition) *SelectBuilder {
+	return b.joinTable("FULL OUTER", table, alias, conds)
+}
+
+// JoinUsing appends the "JOIN table USING (columns...)" statement.
+func (b *SelectBuilder) JoinUsing(table, alias string, columns ...string) *SelectBuilder {
+	b = b.writable()
+	b.joins = append(b.joins, joinTable{Table: table, Alias: alias, Using: columns})
+	return b
 }
 
-func (b *SelectBuilder) joinTable(cmd, table, alias string, ons ...JoinOn) *SelectBuilder {
+// JoinCross appends the "CROSS JOIN table" statement.
+func (b *SelectBuilder) JoinCross(table, alias string) *SelectBuilder {
+	return b.joinTable("CROSS", table, alias, nil)
+}
+
+// JoinNatural appends the "NATURAL JOIN table" statement.
+func (b *SelectBuilder) JoinNatural(table, alias string) *SelectBuilder {
+	return b.joinTable("NATURAL", table, alias, nil)
+}
+
+func (b *SelectBuilder) joinTable(cmd, table, alias string, ons []Condition) *SelectBuilder {
+	b = b.writable()
 	b.joins = append(b.joins, joinTable{Type: cmd, Table: table, Alias: alias, Ons: ons})
 	return b
 }
 
 // Where sets the WHERE conditions.
 func (b *SelectBuilder) Where(andConditions ...Condition) *SelectBuilder {
+	b = b.writable()
 	b.wheres = append(b.wheres, andConditions...)
 	return b
 }
 
 // WhereNamedArgs is the same as Where, but uses the NamedArg as the condition.
 func (b *SelectBuilder) WhereNamedArgs(args ...NamedArg) *SelectBuilder {
-	for _, arg := range args {
-		b.Where(b.Equal(arg.Name(), arg.Get()))
+	conds := make([]Condition, len(args))
+	for i, arg := range args {
+		conds[i] = b.Equal(arg.Name(), arg.Get())
 	}
-	return b
+	return b.Where(conds...)
 }
 
 // GroupBy resets the GROUP BY columns.
 func (b *SelectBuilder) GroupBy(columns ...string) *SelectBuilder {
-	b.groupbys = columns
+	b = b.writable()
+	b.groupbys = make([]Expression, len(columns))
+	for i, column := range columns {
+		b.groupbys[i] = Ident(column)
+	}
 	return b
 }
 
-// Having appends the HAVING expression.
+// GroupByExpr appends the expressions used by GROUP BY, such as
+// Expr("DATE(?)", Ident("created_at")).
+func (b *SelectBuilder) GroupByExpr(exprs ...Expression) *SelectBuilder {
+	b = b.writable()
+	b.groupbys = append(b.groupbys, exprs...)
+	return b
+}
+
+// Having appends the raw HAVING expressions, which are not quoted.
+//
+// Notice: exprs must not contain the input from the untrusted user.
+// Use HavingCondition instead.
 func (b *SelectBuilder) Having(exprs ...string) *SelectBuilder {
-	b.havings = append(b.havings, exprs...)
+	b = b.writable()
+	for _, expr := range exprs {
+		b.havings = append(b.havings, Raw(expr))
+	}
+	return b
+}
+
+// HavingCondition appends the HAVING conditions, which share the arguments
+// with WHERE and are joined by AND. For example,
+//
+//	HavingCondition(CompareExpr(Count("*"), ">", 10), CompareExpr(Avg("age"), "<=", 30))
+//	// HAVING COUNT(*)>? AND AVG(`age`)<=?
+func (b *SelectBuilder) HavingCondition(conds ...Condition) *SelectBuilder {
+	b = b.writable()
+	b.havings = append(b.havings, conds...)
 	return b
 }
 
 // OrderBy appends the column used by ORDER BY.
 func (b *SelectBuilder) OrderBy(column string, order ...Order) *SelectBuilder {
+	b = b.writable()
 	ob := orderby{Column: column}
 	if len(order) > 0 {
 		ob.Order = order[0]
@@ -323,6 +671,25 @@ func (b *SelectBuilder) OrderBy(column string, order ...Order) *SelectBuilder {
 	return b
 }
 
+// OrderByRaw appends the raw expression used by ORDER BY, which is not quoted.
+//
+// Notice: expr must not contain the input from the untrusted user.
+func (b *SelectBuilder) OrderByRaw(expr string, order ...Order) *SelectBuilder {
+	return b.OrderByExpr(Raw(expr), order...)
+}
+
+// OrderByExpr appends the expression used by ORDER BY, such as
+// Expr("FIELD(?, ?, ?)", Ident("status"), 2, 1).
+func (b *SelectBuilder) OrderByExpr(expr Expression, order ...Order) *SelectBuilder {
+	b = b.writable()
+	ob := orderby{Expr: expr}
+	if len(order) > 0 {
+		ob.Order = order[0]
+	}
+	b.orderbys = append(b.orderbys, ob)
+	return b
+}
+
 // OrderByDesc appends the column used by ORDER BY DESC.
 func (b *SelectBuilder) OrderByDesc(column string) *SelectBuilder {
 	return b.OrderBy(column, Desc)
@@ -335,12 +702,14 @@ func (b *SelectBuilder) OrderByAsc(column string) *SelectBuilder {
 
 // Limit sets the LIMIT to limit.
 func (b *SelectBuilder) Limit(limit int64) *SelectBuilder {
+	b = b.writable()
 	b.limit = limit
 	return b
 }
 
 // Offset sets the OFFSET to offset.
 func (b *SelectBuilder) Offset(offset int64) *SelectBuilder {
+	b = b.writable()
 	b.offset = offset
 	return b
 }
@@ -349,7 +718,43 @@ func (b *SelectBuilder) Offset(offset int64) *SelectBuilder {
 //
 // Notice: pageNum starts with 0.
 func (b *SelectBuilder) Paginate(pageNum, pageSize int64) *SelectBuilder {
-	b.Limit(pageSize).Offset(pageNum * pageSize)
+	return b.Limit(pageSize).Offset(pageNum * pageSize)
+}
+
+// ForUpdate locks the selected rows by "FOR UPDATE [OF tables...]",
+// which is placed after LIMIT. But Oracle does not support it with LIMIT
+// or OFFSET, that's, "FETCH FIRST n ROWS ONLY".
+//
+// Notice: "OF" is followed by the tables for PostgreSQL and MySQL,
+// but the columns for Oracle.
+func (b *SelectBuilder) ForUpdate(of ...string) *SelectBuilder {
+	b = b.writable()
+	b.lock = "UPDATE"
+	b.lockOf = of
+	return b
+}
+
+// ForShare is the same as ForUpdate, but uses "FOR SHARE" instead.
+func (b *SelectBuilder) ForShare(of ...string) *SelectBuilder {
+	b = b.writable()
+	b.lock = "SHARE"
+	b.lockOf = of
+	return b
+}
+
+// NoWait appends "NOWAIT" to the row locking clause set by ForUpdate
+// or ForShare, which fails immediately if the rows have been locked.
+func (b *SelectBuilder) NoWait() *SelectBuilder {
+	b = b.writable()
+	b.lockWait = "NOWAIT"
+	return b
+}
+
+// SkipLocked appends "SKIP LOCKED" to the row locking clause set by ForUpdate
+// or ForShare, which skips the rows that have been locked.
+func (b *SelectBuilder) SkipLocked() *SelectBuilder {
+	b = b.writable()
+	b.lockWait = "SKIP LOCKED"
 	return b
 }
 
@@ -360,9 +765,13 @@ func (b *SelectBuilder) Query() (Rows, error) {
 
 // QueryContext builds the sql and executes it by *sql.DB.
 func (b *SelectBuilder) QueryContext(ctx context.Context) (Rows, error) {
-	query, args := b.Build()
+	query, args, err := b.BuildE()
+	if err != nil {
+		return Rows{SelectBuilder: b}, err
+	}
+
 	rows, err := b.executor.QueryContext(ctx, query, args...)
-	return Rows{b, rows}, err
+	return Rows{SelectBuilder: b, Rows: rows}, err
 }
 
 // QueryRow builds the sql and executes it by *sql.DB.
@@ -372,28 +781,148 @@ func (b *SelectBuilder) QueryRow() Row {
 
 // QueryRowContext builds the sql and executes it by *sql.DB.
 func (b *SelectBuilder) QueryRowContext(ctx context.Context) Row {
-	query, args := b.Build()
-	return Row{b, b.executor.QueryRowContext(ctx, query, args...)}
+	query, args, err := b.BuildE()
+	if err != nil {
+		return Row{SelectBuilder: b, err: err}
+	}
+	return Row{SelectBuilder: b, Row: b.executor.QueryRowContext(ctx, query, args...)}
 }
 
 // SetExecutor sets the executor to exec.
 func (b *SelectBuilder) SetExecutor(exec Executor) *SelectBuilder {
+	b = b.writable()
 	b.executor = exec
 	return b
 }
 
 // SetInterceptor sets the interceptor to f.
 func (b *SelectBuilder) SetInterceptor(f Interceptor) *SelectBuilder {
+	b = b.writable()
 	b.intercept = f
 	return b
 }
 
 // SetDialect resets the dialect.
 func (b *SelectBuilder) SetDialect(dialect Dialect) *SelectBuilder {
+	b = b.writable()
 	b.dialect = dialect
 	return b
 }
 
+// Clone returns a deep copy of the builder, which can be modified without
+// affecting the original. So a base query can be cloned and then extended
+// for each request. For example,
+//
+//	base := Selects("id", "name").From("users").Where(Equal("status", 1))
+//	q1 := base.Clone().Where(Equal("area", "a")).Limit(10)
+//	q2 := base.Clone().OrderByDesc("id")
+//
+// The nested SELECT queries are also cloned, but the conditions and
+// the expressions are shared, which should not be modified after being added.
+// The returned builder is always mutable even if b is immutable.
+func (b *SelectBuilder) Clone() *SelectBuilder {
+	c := *b
+	c.immutable = false
+	c.withs = b.withs.Clone()
+	c.tables = cloneTables(b.tables)
+	c.columns = append([]selectedColumn(nil), b.columns...)
+	c.joins = cloneJoins(b.joins)
+	c.wheres = append([]Condition(nil), b.wheres...)
+	c.groupbys = append([]Expression(nil), b.groupbys...)
+	c.havings = append([]Condition(nil), b.havings...)
+	c.orderbys = append([]orderby(nil), b.orderbys...)
+	c.lockOf = append([]string(nil), b.lockOf...)
+
+	for i, column := range c.columns {
+		if column.Window != nil {
+			c.columns[i].Window = column.Window.Clone()
+		}
+	}
+
+	if len(b.windows) > 0 {
+		c.windows = make([]namedWindow, len(b.windows))
+		for i, w := range b.windows {
+			c.windows[i] = namedWindow{Name: w.Name, Window: w.Window.Clone()}
+		}
+	}
+
+	if b.seek != nil {
+		seek := *b.seek
+		seek.Values = append([]interface{}(nil), b.seek.Values...)
+		c.seek = &seek
+	}
+
+	return &c
+}
+
+// Immutable returns an immutable clone of the builder, which is copied
+// on write. That's, each chained method, such as Where and Limit, returns
+// a new immutable builder instead of modifying itself. So the returned builder
+// can be shared, for example, by the concurrent HTTP handlers. For example,
+//
+//	var baseQuery = Selects("id", "name").From("users").Where(Equal("status", 1)).Immutable()
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//		q := baseQuery.Where(Equal("area", r.FormValue("area"))).Limit(10)
+//		// baseQuery is not modified.
+//	}
+//
+// Call Clone to get a mutable builder again.
+func (b *SelectBuilder) Immutable() *SelectBuilder {
+	c := b.Clone()
+	c.immutable = true
+	return c
+}
+
+// writable returns the builder to be modified, which is b itself,
+// or the immutable clone of b if b is immutable.
+func (b *SelectBuilder) writable() *SelectBuilder {
+	if b.immutable {
+		return b.Immutable()
+	}
+	return b
+}
+
+func cloneTables(tables []sqlTable) []sqlTable {
+	if len(tables) == 0 {
+		return nil
+	}
+
+	_tables := make([]sqlTable, len(tables))
+	for i, table := range tables {
+		if table.Query != nil {
+			table.Query = cloneBuilder(table.Query)
+		}
+		_tables[i] = table
+	}
+	return _tables
+}
+
+func cloneJoins(joins []joinTable) []joinTable {
+	if len(joins) == 0 {
+		return nil
+	}
+
+	_joins := make([]joinTable, len(joins))
+	for i, join := range joins {
+		if join.Query != nil {
+			join.Query = cloneBuilder(join.Query)
+		}
+		join.Ons = append([]Condition(nil), join.Ons...)
+		join.Using = append([]string(nil), join.Using...)
+		_joins[i] = join
+	}
+	return _joins
+}
+
+// BuildE is the same as Build, but returns the error instead of panicking,
+// which is BuildError or UnsupportedError.
+func (b *SelectBuilder) BuildE() (sql string, args []interface{}, err error) {
+	defer recoverBuildError(&err)
+	sql, args = b.Build()
+	return
+}
+
 // String is the same as b.Build(), except args.
 func (b *SelectBuilder) String() string {
 	sql, _ := b.Build()
@@ -402,30 +931,47 @@ func (b *SelectBuilder) String() string {
 
 // Build builds the SELECT sql statement.
 func (b *SelectBuilder) Build() (sql string, args []interface{}) {
-	if len(b.tables) == 0 {
-		panic("SelectBuilder: no table names")
+	dialect := b.dialect
+	if dialect == nil {
+		dialect = DefaultDialect
+	}
+
+	ab := NewArgsBuilder(dialect)
+	sql = b.build(ab)
+	return intercept(b.intercept, sql, ab.Args())
+}
+
+// BuildNested implements the interface NestedBuilder, which builds
+// the SELECT sql statement with the dialect of ab, not the interceptor.
+func (b *SelectBuilder) BuildNested(ab *ArgsBuilder) string {
+	return b.build(ab)
+}
+
+func (b *SelectBuilder) build(ab *ArgsBuilder) (sql string) {
+	if b.err != nil {
+		panic(b.err)
+	} else if len(b.tables) == 0 {
+		panic(buildErrorf("SelectBuilder", ErrNoTable, "no table names"))
 	} else if len(b.columns) == 0 {
-		panic("SelectBuilder: no selected columns")
+		panic(buildErrorf("SelectBuilder", ErrNoColumns, "no selected columns"))
 	}
 
 	buf := getBuffer()
+	b.withs.Build(buf, ab)
 	buf.WriteString("SELECT ")
 
 	if b.distinct {
 		buf.WriteString("DISTINCT ")
 	}
 
-	dialect := b.dialect
-	if dialect == nil {
-		dialect = DefaultDialect
-	}
+	dialect := ab.Dialect
 
 	// Selected Columns
 	for i, column := range b.columns {
 		if i > 0 {
 			buf.WriteString(", ")
 		}
-		buf.WriteString(dialect.Quote(column.Column))
+		buf.WriteString(column.Build(ab))
 		if column.Alias != "" {
 			buf.WriteString(" AS ")
 			buf.WriteString(dialect.Quote(column.Alias))
@@ -438,60 +984,108 @@ func (b *SelectBuilder) Build() (sql string, args []interface{}) {
 		if i > 0 {
 			buf.WriteString(", ")
 		}
-		buf.WriteString(dialect.Quote(table.Table))
-		if table.Alias != "" {
-			buf.WriteString(" AS ")
-			buf.WriteString(dialect.Quote(table.Alias))
-		}
+		table.Build(buf, ab)
 	}
 
 	// Join
 	for _, join := range b.joins {
-		join.Build(buf, dialect)
+		join.Build(buf, ab)
 	}
 
 	// Where
-	if _len := len(b.wheres); _len > 0 {
-		expr := b.wheres[0]
+	wheres := b.wheres
+	orderbys := b.getSeekOrderBys()
+	if cond := b.getSeekCondition(orderbys); cond != nil {
+		wheres = append(wheres[:len(wheres):len(wheres)], cond)
+	}
+
+	if _len := len(wheres); _len > 0 {
+		expr := wheres[0]
 		if _len > 1 {
-			expr = And(b.wheres...)
+			expr = And(wheres...)
 		}
 
 		buf.WriteString(" WHERE ")
-		ab := NewArgsBuilder(dialect)
 		buf.WriteString(expr.Build(ab))
-		args = ab.Args()
 	}
 
 	// Group By & Having By
 	if len(b.groupbys) > 0 {
 		buf.WriteString(" GROUP BY ")
-		for i, s := range b.groupbys {
+		for i, expr := range b.groupbys {
 			if i > 0 {
 				buf.WriteString(", ")
 			}
-			buf.WriteString(dialect.Quote(s))
+			buf.WriteString(expr.Build(ab))
 		}
 
 		if len(b.havings) > 0 {
 			buf.WriteString(" HAVING ")
-			for i, s := range b.havings {
+			for i, cond := range b.havings {
 				if i > 0 {
 					buf.WriteString(" AND ")
 				}
-				buf.WriteString(s)
+				buf.WriteString(cond.Build(ab))
 			}
 		}
 	}
 
-	// Order By
-	if len(b.orderbys) > 0 {
+	// Window
+	buildWindows(buf, ab, b.windows)
+
+	// Order By & Limit & Offset
+	buildOrderByLimit(buf, ab, orderbys, b.limit, b.offset)
+
+	// Lock
+	if b.lock != "" {
+		b.addLock(buf, dialect)
+	} else if b.lockWait != "" {
+		panic(buildErrorf("SelectBuilder", ErrInvalidStatement,
+			"%s requires FOR UPDATE or FOR SHARE", b.lockWait))
+	}
+
+	sql = buf.String()
+	putBuffer(buf)
+	return
+}
+
+func (b *SelectBuilder) addLock(buf *bytes.Buffer, dialect Dialect) {
+	caps := GetCapabilities(dialect)
+	if b.lock == "UPDATE" && !caps.LockForUpdate {
+		panic(unsupported(dialect, "FOR UPDATE"))
+	} else if b.lock == "SHARE" && !caps.LockForShare {
+		panic(unsupported(dialect, "FOR SHARE"))
+	} else if (b.limit > 0 || b.offset > 0) && !caps.LockWithLimit {
+		panic(unsupported(dialect, "FOR "+b.lock+" with row limiting"))
+	}
+
+	buf.WriteString(" FOR ")
+	buf.WriteString(b.lock)
+	for i, of := range b.lockOf {
+		if i == 0 {
+			buf.WriteString(" OF ")
+		} else {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(dialect.Quote(of))
+	}
+
+	if b.lockWait != "" {
+		buf.WriteByte(' ')
+		buf.WriteString(b.lockWait)
+	}
+}
+
+func buildOrderByLimit(buf *bytes.Buffer, ab *ArgsBuilder, orderbys []orderby,
+	limit, offset int64) {
+	dialect := ab.Dialect
+	if len(orderbys) > 0 {
 		buf.WriteString(" ORDER BY ")
-		for i, ob := range b.orderbys {
+		for i, ob := range orderbys {
 			if i > 0 {
 				buf.WriteString(", ")
 			}
-			buf.WriteString(dialect.Quote(ob.Column))
+			buf.WriteString(ob.Build(ab))
 			if ob.Order != "" {
 				buf.WriteByte(' ')
 				buf.WriteString(string(ob.Order))
@@ -499,21 +1093,24 @@ func (b *SelectBuilder) Build() (sql string, args []interface{}) {
 		}
 	}
 
-	// Limit & Offset
-	if b.limit > 0 || b.offset > 0 {
+	if limit > 0 || offset > 0 {
+		// Such as SQL Server, OFFSET ... FETCH must follow ORDER BY.
+		if len(orderbys) == 0 && GetCapabilities(dialect).OffsetRequiresOrderBy {
+			buf.WriteString(" ORDER BY (SELECT NULL)")
+		}
+
 		buf.WriteByte(' ')
-		buf.WriteString(dialect.LimitOffset(b.limit, b.offset))
+		buf.WriteString(dialect.LimitOffset(limit, offset))
 	}
-
-	sql = buf.String()
-	putBuffer(buf)
-	return intercept(b.intercept, sql, args)
 }
 
 // Row is used to wrap sql.Row.
 type Row struct {
 	*SelectBuilder
 	*sql.Row
+
+	// err is the error to build the query, which is returned by Scan.
+	err error
 }
 
 // Rows is used to wrap sql.Rows.
@@ -522,9 +1119,21 @@ type Rows struct {
 	*sql.Rows
 }
 
+// Scan is the same as sql.Row.Scan, but returns the error to build
+// the query first if failing to build it.
+func (r Row) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.Row.Scan(dest...)
+}
+
 // ScanStruct is the same as Scan, but the columns are scanned into the struct
 // s, which uses ScanColumnsToStruct.
 func (r Row) ScanStruct(s interface{}) (err error) {
+	if r.err != nil {
+		return r.err
+	}
 	return ScanColumnsToStruct(r.Scan, r.SelectedColumns(), s)
 }
 
@@ -536,45 +1145,16 @@ func (r Rows) ScanStruct(s interface{}) (err error) {
 
 // ScanColumnsToStruct scans the columns into the fields of the struct s,
 // which supports the tag named "sql" to modify the field name. If the value
-// of the tag is "-", however, the field will be ignored.
+// of the tag is "-", however, the field will be ignored. If there is no field
+// for a column, return an error.
+//
+// The metadata of the struct is parsed once and cached per type.
 func ScanColumnsToStruct(scan func(...interface{}) error, columns []string,
 	s interface{}) (err error) {
-	fields := getFields(s)
-	vs := make([]interface{}, len(columns))
-	for i, c := range columns {
-		vs[i] = fields[c].Addr().Interface()
+	v := getStructValue(s)
+	plan, err := newScanPlan(v.Type(), columns)
+	if err != nil {
+		return err
 	}
-	return scan(vs...)
-}
-
-func getFields(s interface{}) map[string]reflect.Value {
-	v := reflect.ValueOf(s)
-	if v.Kind() != reflect.Ptr {
-		panic("not a pointer to struct")
-	} else if v = v.Elem(); v.Kind() != reflect.Struct {
-		panic("not a pointer to struct")
-	}
-
-	vt := v.Type()
-	_len := v.NumField()
-	vs := make(map[string]reflect.Value, _len)
-	for i := 0; i < _len; i++ {
-		vft := vt.Field(i)
-		name := vft.Name
-
-		tag := vft.Tag.Get("sql")
-		if index := strings.IndexByte(tag, ','); index > -1 {
-			tag = strings.TrimSpace(tag[:index])
-		}
-
-		if tag == "-" {
-			continue
-		} else if tag != "" {
-			name = tag
-		}
-
-		vs[name] = v.Field(i)
-	}
-
-	return vs
+	return scan(plan.Dests(v, make([]interface{}, len(plan)))...)
 }
@@ -0,0 +1,133 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+)
+
+// derive returns a clone of the builder without ORDER BY, LIMIT, OFFSET,
+// the cursor of Seek and the row locking clause, which keeps the tables,
+// the joins and the conditions.
+func (b *SelectBuilder) derive() *SelectBuilder {
+	q := b.Clone()
+	q.immutable = b.immutable
+	q.orderbys = nil
+	q.limit = 0
+	q.offset = 0
+	q.seek = nil
+	q.lock = ""
+	q.lockOf = nil
+	q.lockWait = ""
+	return q
+}
+
+// CountQuery returns a new SELECT builder, which counts the rows matched by
+// the current builder without ORDER BY, LIMIT and OFFSET. For example,
+//
+//	Selects("id", "name").From("users").Where(Equal("status", 1)).
+//		OrderBy("id").Limit(10).CountQuery()
+//	// SELECT COUNT(*) FROM `users` WHERE `status`=?
+//
+// If DISTINCT or GROUP BY is present, the query is wrapped in a subquery,
+// and the common table expressions are moved to the outer query. For example,
+//
+//	Selects("area").From("users").GroupBy("area").CountQuery()
+//	// SELECT COUNT(*) FROM (SELECT `area` FROM `users` GROUP BY `area`) AS `t`
+func (b *SelectBuilder) CountQuery() *SelectBuilder {
+	q := b.derive()
+	count := []selectedColumn{{Expr: Count("*")}}
+	if !q.distinct && len(q.groupbys) == 0 {
+		q.columns = count
+		q.windows = nil
+		return q
+	}
+
+	withs := q.withs
+	q.withs = commonTables{}
+	return &SelectBuilder{
+		intercept: b.intercept,
+		executor:  b.executor,
+		dialect:   b.dialect,
+		withs:     withs,
+		tables:    []sqlTable{{Query: q, Alias: "t"}},
+		columns:   count,
+		immutable: b.immutable,
+	}
+}
+
+// ExistsQuery returns a new SELECT builder, which checks whether there is
+// any row matched by the current builder without ORDER BY, LIMIT and OFFSET.
+// For example,
+//
+//	Selects("id", "name").From("users").Where(Equal("status", 1)).
+//		OrderBy("id").Limit(10).ExistsQuery()
+//	// SELECT 1 FROM `users` WHERE `status`=? LIMIT 1
+func (b *SelectBuilder) ExistsQuery() *SelectBuilder {
+	q := b.derive()
+	q.columns = []selectedColumn{{Column: "1"}}
+	q.windows = nil
+	q.limit = 1
+	return q
+}
+
+// QueryCount is equal to b.QueryCountContext(context.Background()).
+func (b *SelectBuilder) QueryCount() (total int64, err error) {
+	return b.QueryCountContext(context.Background())
+}
+
+// QueryCountContext executes the query built by CountQuery and returns
+// the number of the matched rows.
+func (b *SelectBuilder) QueryCountContext(ctx context.Context) (total int64, err error) {
+	err = b.CountQuery().QueryRowContext(ctx).Scan(&total)
+	return
+}
+
+// QueryExists is equal to b.QueryExistsContext(context.Background()).
+func (b *SelectBuilder) QueryExists() (exist bool, err error) {
+	return b.QueryExistsContext(context.Background())
+}
+
+// QueryExistsContext executes the query built by ExistsQuery and reports
+// whether there is any matched row.
+func (b *SelectBuilder) QueryExistsContext(ctx context.Context) (exist bool, err error) {
+	var one int
+	switch err = b.ExistsQuery().QueryRowContext(ctx).Scan(&one); err {
+	case nil:
+		return true, nil
+	case sql.ErrNoRows:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// BindRowsWithTotal is equal to
+// b.BindRowsWithTotalContext(context.Background(), slice).
+func (b *SelectBuilder) BindRowsWithTotal(slice interface{}) (total int64, err error) {
+	return b.BindRowsWithTotalContext(context.Background(), slice)
+}
+
+// BindRowsWithTotalContext is the same as BindRowsContext, but also returns
+// the total number of the rows without LIMIT and OFFSET by QueryCountContext,
+// which is used by the paginated list. If total is 0, the page is not queried.
+func (b *SelectBuilder) BindRowsWithTotalContext(ctx context.Context,
+	slice interface{}) (total int64, err error) {
+	if total, err = b.QueryCountContext(ctx); err == nil && total > 0 {
+		err = b.BindRowsContext(ctx, slice)
+	}
+	return
+}
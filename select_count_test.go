@@ -0,0 +1,111 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import (
+	"fmt"
+	"testing"
+)
+
+func ExampleSelectBuilder_CountQuery() {
+	active := Selects("id").From("users").Where(Equal("status", 1))
+	s1 := Selects("u.id", "u.name").With("active", active).From("users", "u").
+		Join("active", "a", On("u.id", "a.id")).Where(Like("u.name", "%abc%")).
+		OrderBy("u.id").Limit(10).Offset(20)
+	s2 := Selects("area").From("users").Where(Greater("age", 20)).
+		GroupBy("area").HavingCondition(CompareExpr(Count("*"), ">", 10)).
+		OrderBy("area").Limit(10)
+
+	sql1, args1 := s1.CountQuery().SetDialect(Postgres).Build()
+	sql2, args2 := s2.CountQuery().SetDialect(Postgres).Build()
+	sql3, args3 := s1.Distinct().CountQuery().Build()
+
+	fmt.Println(sql1)
+	fmt.Println(args1)
+
+	fmt.Println(sql2)
+	fmt.Println(args2)
+
+	fmt.Println(sql3)
+	fmt.Println(args3)
+
+	// The original builder is not changed.
+	fmt.Println(s2.SetDialect(Postgres).String())
+
+	// Output:
+	// WITH "active" AS (SELECT "id" FROM "users" WHERE "status"=$1) SELECT COUNT(*) FROM "users" AS "u" JOIN "active" AS "a" ON "u"."id"="a"."id" WHERE "u"."name" LIKE $2
+	// [1 %abc%]
+	// SELECT COUNT(*) FROM (SELECT "area" FROM "users" WHERE "age">$1 GROUP BY "area" HAVING COUNT(*)>$2) AS "t"
+	// [20 10]
+	// WITH `active` AS (SELECT `id` FROM `users` WHERE `status`=?) SELECT COUNT(*) FROM (SELECT DISTINCT `u`.`id` AS `id`, `u`.`name` AS `name` FROM `users` AS `u` JOIN `active` AS `a` ON `u`.`id`=`a`.`id` WHERE `u`.`name` LIKE ?) AS `t`
+	// [1 %abc%]
+	// SELECT "area" FROM "users" WHERE "age">$1 GROUP BY "area" HAVING COUNT(*)>$2 ORDER BY "area" LIMIT 10
+}
+
+func ExampleSelectBuilder_ExistsQuery() {
+	s := Selects("id", "name").From("users").Where(Equal("status", 1)).
+		OrderBy("id").Limit(10).Offset(20)
+
+	sql1, args1 := s.ExistsQuery().Build()
+	sql2, args2 := s.ExistsQuery().SetDialect(MSSQL).Build()
+
+	fmt.Println(sql1)
+	fmt.Println(args1)
+
+	fmt.Println(sql2)
+	fmt.Println(args2)
+
+	// Output:
+	// SELECT 1 FROM `users` WHERE `status`=? LIMIT 1
+	// [1]
+	// SELECT 1 FROM [users] WHERE [status]=@p1 ORDER BY (SELECT NULL) OFFSET 0 ROWS FETCH NEXT 1 ROWS ONLY
+	// [1]
+}
+
+func TestSelectBuilderBindRowsWithTotal(t *testing.T) {
+	db := newIDDriver([]int64{3}, []int64{1, 2}, []int64{0}, []int64{}).DB(MySQL)
+	defer db.Close()
+
+	var ids []int64
+	total, err := db.Select("id").From("t").Limit(2).BindRowsWithTotal(&ids)
+	if err != nil {
+		t.Fatal(err)
+	} else if total != 3 {
+		t.Errorf("expect the total 3, but got %d", total)
+	} else if fmt.Sprint(ids) != "[1 2]" {
+		t.Errorf("expect the ids [1 2], but got %v", ids)
+	}
+
+	ids = nil
+	total, err = db.Select("id").From("t").Limit(2).BindRowsWithTotal(&ids)
+	if err != nil {
+		t.Fatal(err)
+	} else if total != 0 || len(ids) != 0 {
+		t.Errorf("expect no rows, but got %d: %v", total, ids)
+	}
+
+	if exist, err := db.Select("id").From("t").QueryExists(); err != nil {
+		t.Fatal(err)
+	} else if exist {
+		t.Error("expect no rows, but got one")
+	}
+
+	// The promoted ConditionSet.Exists is not shadowed.
+	s := Select("id").From("t")
+	expected := "SELECT `id` FROM `t` WHERE EXISTS (SELECT `id` FROM `u`)"
+	if sql := s.Where(s.Exists(Select("id").From("u"))).String(); sql != expected {
+		t.Errorf("expected '%s', got '%s'", expected, sql)
+	}
+}
@@ -50,16 +50,22 @@ func (r Rows) ScanSlice(slice interface{}) (err error) {
 		panic("Rows.ScanSlice: the value must be a pointer to a slice")
 	}
 
+	// The scan plan of the struct is computed once and reused for all the rows.
+	var plan scanPlan
+	var dests []interface{}
 	et := vf.Type().Elem()
-	var elemIsStruct bool
-	if et.Kind() == reflect.Struct {
-		elemIsStruct = true
+	elemIsStruct := et.Kind() == reflect.Struct
+	if elemIsStruct {
+		if plan, err = newScanPlan(et, r.SelectedColumns()); err != nil {
+			return err
+		}
+		dests = make([]interface{}, len(plan))
 	}
 
 	for r.Next() {
 		e := reflect.New(et)
 		if elemIsStruct {
-			if err := r.ScanStruct(e.Interface()); err != nil {
+			if err := r.Scan(plan.Dests(e.Elem(), dests)...); err != nil {
 				return err
 			}
 		} else {
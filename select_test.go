@@ -16,6 +16,8 @@ package sqlx
 
 import (
 	"fmt"
+	"sync"
+	"testing"
 )
 
 func ExampleSelectBuilder() {
@@ -64,6 +66,20 @@ func ExampleSelectBuilder_GroupBy() {
 	// [123]
 }
 
+func ExampleSelectBuilder_HavingCondition() {
+	s := Selects("area").SelectExpr(Count("*"), "total").SelectExpr(Avg("age")).From("users").
+		Where(Equal("status", 1)).GroupBy("area").
+		HavingCondition(CompareExpr(Count("*"), ">", 10), CompareExpr(Avg("age"), "<=", 30))
+	sql, args := s.SetDialect(Postgres).Build()
+
+	fmt.Println(sql)
+	fmt.Println(args)
+
+	// Output:
+	// SELECT "area", COUNT(*) AS "total", AVG("age") FROM "users" WHERE "status"=$1 GROUP BY "area" HAVING COUNT(*)>$2 AND AVG("age")<=$3
+	// [1 10 30]
+}
+
 func ExampleSelectBuilder_OrderBy() {
 	s1 := Select("*").From("table").Where(Equal("id", 123)).OrderBy("time")
 	s2 := Select("*").From("table").Where(Equal("id", 123)).OrderBy("time", Desc)
@@ -96,6 +112,26 @@ func ExampleSelectBuilder_Limit() {
 	// [123]
 }
 
+func ExampleSelectBuilder_Paginate() {
+	s1 := Select("*").From("table").Where(Equal("id", 123)).Paginate(2, 10)
+	s2 := Select("*").From("table").Where(Equal("id", 123)).OrderBy("time").Paginate(2, 10)
+
+	sql1, args1 := s1.SetDialect(MSSQL).Build()
+	sql2, args2 := s2.SetDialect(MSSQL).Build()
+
+	fmt.Println(sql1)
+	fmt.Println(args1)
+
+	fmt.Println(sql2)
+	fmt.Println(args2)
+
+	// Output:
+	// SELECT * FROM [table] WHERE [id]=@p1 ORDER BY (SELECT NULL) OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY
+	// [123]
+	// SELECT * FROM [table] WHERE [id]=@p1 ORDER BY [time] OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY
+	// [123]
+}
+
 func ExampleSelectBuilder_Join() {
 	s := Select("*").From("table1").Join("table2", "", On("table1.id", "table2.id")).
 		Where(Equal("table1.id", 123)).OrderBy("table1.time").Limit(10).Offset(100)
@@ -109,6 +145,150 @@ func ExampleSelectBuilder_Join() {
 	// [123]
 }
 
+func TestSelectBuilderJoinOns(t *testing.T) {
+	// The JoinOns built elsewhere are still passed to Join as before.
+	ons := []JoinOn{On("t1.id", "t2.id"), On("t1.type", "t2.type")}
+	s := Selects("t1.id").From("t1").JoinLeft("t2", "", ons...).
+		JoinCond("t3", "", On("t3.id", "t1.id"), Equal("t3.status", 1))
+
+	expected := "SELECT `t1`.`id` AS `id` FROM `t1` LEFT JOIN `t2` ON `t1`.`id`=`t2`.`id` AND `t1`.`type`=`t2`.`type` JOIN `t3` ON `t3`.`id`=`t1`.`id` AND `t3`.`status`=?"
+	if sql := s.String(); sql != expected {
+		t.Errorf("expected '%s', got '%s'", expected, sql)
+	}
+}
+
+func ExampleSelectBuilder_With() {
+	active := Selects("id", "name").From("users").Where(Equal("status", 1))
+	s := Selects("a.id", "a.name", "o.amount").With("active", active).From("active", "a").
+		Join("orders", "o", On("o.user_id", "a.id")).Where(Greater("o.amount", 100))
+	sql, args := s.SetDialect(Postgres).Build()
+
+	fmt.Println(sql)
+	fmt.Println(args)
+
+	// Output:
+	// WITH "active" AS (SELECT "id", "name" FROM "users" WHERE "status"=$1) SELECT "a"."id" AS "id", "a"."name" AS "name", "o"."amount" AS "amount" FROM "active" AS "a" JOIN "orders" AS "o" ON "o"."user_id"="a"."id" WHERE "o"."amount">$2
+	// [1 100]
+}
+
+func ExampleSelectBuilder_WithRecursive() {
+	base := Selects("id", "parent_id").From("categories").Where(Equal("id", 1))
+	children := Selects("c.id", "c.parent_id").From("categories", "c").
+		Join("tree", "t", On("c.parent_id", "t.id"))
+
+	tree := UnionAll(base, children)
+	s := Selects("id").WithRecursive("tree", tree, "id", "parent_id").From("tree")
+
+	sql1, args1 := s.SetDialect(Postgres).Build()
+	sql2, args2 := s.SetDialect(MSSQL).Build()
+	sql3, args3 := s.SetDialect(Oracle).Build()
+
+	fmt.Println(sql1)
+	fmt.Println(args1)
+
+	fmt.Println(sql2)
+	fmt.Println(args2)
+
+	fmt.Println(sql3)
+	fmt.Println(args3)
+
+	// Output:
+	// WITH RECURSIVE "tree" ("id", "parent_id") AS (SELECT "id", "parent_id" FROM "categories" WHERE "id"=$1 UNION ALL SELECT "c"."id" AS "id", "c"."parent_id" AS "parent_id" FROM "categories" AS "c" JOIN "tree" AS "t" ON "c"."parent_id"="t"."id") SELECT "id" FROM "tree"
+	// [1]
+	// WITH [tree] ([id], [parent_id]) AS (SELECT [id], [parent_id] FROM [categories] WHERE [id]=@p1 UNION ALL SELECT [c].[id] AS [id], [c].[parent_id] AS [parent_id] FROM [categories] AS [c] JOIN [tree] AS [t] ON [c].[parent_id]=[t].[id]) SELECT [id] FROM [tree]
+	// [1]
+	// WITH "tree" ("id", "parent_id") AS (SELECT "id", "parent_id" FROM "categories" WHERE "id"=:1 UNION ALL SELECT "c"."id" AS "id", "c"."parent_id" AS "parent_id" FROM "categories" "c" JOIN "tree" "t" ON "c"."parent_id"="t"."id") SELECT "id" FROM "tree"
+	// [1]
+}
+
+func ExampleSelectBuilder_FromSelect() {
+	latest := Select("user_id").SelectExpr(Max("id"), "id").From("orders").
+		Where(Greater("amount", 10)).GroupBy("user_id")
+	paid := Select("order_id").From("payments").Where(Equal("status", 1))
+
+	s := Selects("o.id", "o.amount").FromSelect(latest, "l").
+		Join("orders", "o", On("o.id", "l.id")).
+		JoinLeftSelect(paid, "p", On("p.order_id", "o.id")).
+		Where(Less("o.amount", 1000))
+
+	sql, args := s.SetDialect(Postgres).Build()
+	fmt.Println(sql)
+	fmt.Println(args)
+
+	// Output:
+	// SELECT "o"."id" AS "id", "o"."amount" AS "amount" FROM (SELECT "user_id", MAX("id") AS "id" FROM "orders" WHERE "amount">$1 GROUP BY "user_id") AS "l" JOIN "orders" AS "o" ON "o"."id"="l"."id" LEFT JOIN (SELECT "order_id" FROM "payments" WHERE "status"=$2) AS "p" ON "p"."order_id"="o"."id" WHERE "o"."amount"<$3
+	// [10 1 1000]
+}
+
+func ExampleSelectBuilder_JoinUsing() {
+	s := Selects("u.name", "o.amount", "c.code").From("users", "u").
+		JoinLeftCond("orders", "o", On("o.user_id", "u.id"), IsNull("o.deleted_at"), Equal("o.type", 2)).
+		JoinUsing("profiles", "p", "user_id").
+		JoinCross("currencies", "c").
+		Where(Equal("u.status", 1))
+
+	sql, args := s.SetDialect(Postgres).Build()
+	fmt.Println(sql)
+	fmt.Println(args)
+
+	// Output:
+	// SELECT "u"."name" AS "name", "o"."amount" AS "amount", "c"."code" AS "code" FROM "users" AS "u" LEFT JOIN "orders" AS "o" ON "o"."user_id"="u"."id" AND "o"."deleted_at" IS NULL AND "o"."type"=$1 JOIN "profiles" AS "p" USING ("user_id") CROSS JOIN "currencies" AS "c" WHERE "u"."status"=$2
+	// [2 1]
+}
+
+func ExampleSelectBuilder_ForUpdate() {
+	s := Selects("j.id", "j.payload").From("jobs", "j").Join("queues", "q", On("q.id", "j.queue_id")).
+		Where(Equal("j.status", 0)).OrderBy("j.id").Limit(10).ForUpdate("j").SkipLocked()
+
+	sql1, args1 := s.SetDialect(Postgres).Build()
+	sql2, args2 := s.SetDialect(MySQL).Build()
+
+	fmt.Println(sql1)
+	fmt.Println(args1)
+
+	fmt.Println(sql2)
+	fmt.Println(args2)
+
+	// Output:
+	// SELECT "j"."id" AS "id", "j"."payload" AS "payload" FROM "jobs" AS "j" JOIN "queues" AS "q" ON "q"."id"="j"."queue_id" WHERE "j"."status"=$1 ORDER BY "j"."id" LIMIT 10 FOR UPDATE OF "j" SKIP LOCKED
+	// [0]
+	// SELECT `j`.`id` AS `id`, `j`.`payload` AS `payload` FROM `jobs` AS `j` JOIN `queues` AS `q` ON `q`.`id`=`j`.`queue_id` WHERE `j`.`status`=? ORDER BY `j`.`id` LIMIT 10 FOR UPDATE OF `j` SKIP LOCKED
+	// [0]
+}
+
+func ExampleSelectBuilder_SelectOver() {
+	s := Selects("user_id", "amount").From("orders").
+		SelectOver(Raw("ROW_NUMBER()"), NewWindow().PartitionBy("user_id").OrderByDesc("created_at"), "rn").
+		SelectOver(Sum("amount"), NewWindow("w"), "total").
+		SelectOver(Avg("amount"), NewWindow("w").OrderBy("id").Rows(Preceding(2), CurrentRow), "avg").
+		Window("w", NewWindow().PartitionBy("user_id")).
+		Where(Greater("amount", 0))
+
+	sql, args := s.SetDialect(Postgres).Build()
+	fmt.Println(sql)
+	fmt.Println(args)
+
+	// Output:
+	// SELECT "user_id", "amount", ROW_NUMBER() OVER (PARTITION BY "user_id" ORDER BY "created_at" DESC) AS "rn", SUM("amount") OVER "w" AS "total", AVG("amount") OVER ("w" ORDER BY "id" ROWS BETWEEN 2 PRECEDING AND CURRENT ROW) AS "avg" FROM "orders" WHERE "amount">$1 WINDOW "w" AS (PARTITION BY "user_id")
+	// [0]
+}
+
+func TestWindowFrame(t *testing.T) {
+	w := NewWindow().OrderBy("id").Range(UnboundedPreceding, Following(1))
+	s := Selects("id").SelectOver(Sum("amount"), w, "total").From("orders")
+	expected := "SELECT `id`, SUM(`amount`) OVER (ORDER BY `id` RANGE BETWEEN UNBOUNDED PRECEDING AND 1 FOLLOWING) AS `total` FROM `orders`"
+	if sql := s.String(); sql != expected {
+		t.Errorf("expected '%s', got '%s'", expected, sql)
+	}
+
+	w = w.Clone().Rows(CurrentRow, UnboundedFollowing)
+	s = Selects("id").SelectOver(Sum("amount"), w, "total").From("orders")
+	expected = "SELECT `id`, SUM(`amount`) OVER (ORDER BY `id` ROWS BETWEEN CURRENT ROW AND UNBOUNDED FOLLOWING) AS `total` FROM `orders`"
+	if sql := s.String(); sql != expected {
+		t.Errorf("expected '%s', got '%s'", expected, sql)
+	}
+}
+
 func ExampleSelectBuilder_SelectStruct() {
 	type S struct {
 		DefaultField  string
@@ -150,3 +330,60 @@ func ExampleSelectBuilder_SelectStruct() {
 	// b
 	//
 }
+
+func ExampleSelectBuilder_Clone() {
+	base := Selects("id", "name").From("users").Where(Equal("status", 1)).GroupBy("area")
+	q1 := base.Clone().Where(Equal("area", "a")).GroupBy("area", "age").Limit(10)
+	q2 := base.Clone().OrderByDesc("id")
+
+	fmt.Println(base)
+	fmt.Println(q1)
+	fmt.Println(q2)
+
+	// Output:
+	// SELECT `id`, `name` FROM `users` WHERE `status`=? GROUP BY `area`
+	// SELECT `id`, `name` FROM `users` WHERE (`status`=? AND `area`=?) GROUP BY `area`, `age` LIMIT 10
+	// SELECT `id`, `name` FROM `users` WHERE `status`=? GROUP BY `area` ORDER BY `id` DESC
+}
+
+func ExampleSelectBuilder_Immutable() {
+	base := Selects("id", "name").From("users").Where(Equal("status", 1)).Immutable()
+	q1 := base.Where(Equal("area", "a")).Limit(10)
+	q2 := base.OrderByDesc("id").Paginate(2, 10)
+
+	fmt.Println(base)
+	fmt.Println(q1)
+	fmt.Println(q2)
+
+	// Output:
+	// SELECT `id`, `name` FROM `users` WHERE `status`=?
+	// SELECT `id`, `name` FROM `users` WHERE (`status`=? AND `area`=?) LIMIT 10
+	// SELECT `id`, `name` FROM `users` WHERE `status`=? ORDER BY `id` DESC LIMIT 10 OFFSET 20
+}
+
+func TestSelectBuilderImmutable(t *testing.T) {
+	sub := Select("id").From("orders").Where(Greater("amount", 100))
+	base := Selects("id", "name").With("o", sub).From("users").
+		Join("o", "", On("users.id", "o.id")).Where(Equal("status", 1)).Immutable()
+	expected := "WITH `o` AS (SELECT `id` FROM `orders` WHERE `amount`>?) SELECT `id`, `name` FROM `users` JOIN `o` ON `users`.`id`=`o`.`id` WHERE `status`=?"
+
+	// Modifying the nested query does not affect the cloned builder.
+	sub.Where(Less("amount", 1000))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			q := base.Where(Equal("area", i)).OrderBy("id").Limit(int64(i + 1))
+			if _, args := q.Build(); len(args) != 3 || args[2] != i {
+				t.Errorf("unexpected arguments: %v", args)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if sql := base.String(); sql != expected {
+		t.Errorf("expect '%s', but got '%s'", expected, sql)
+	}
+}
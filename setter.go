@@ -40,16 +40,52 @@ func Assign(column string, value interface{}) Setter {
 // Set is the alias of Assign.
 func Set(column string, value interface{}) Setter { return Assign(column, value) }
 
+type exprSetter struct {
+	column string
+	expr   Expression
+}
+
+func (s exprSetter) Build(a *ArgsBuilder) string {
+	return fmt.Sprintf("%s=%s", a.Quote(s.column), s.expr.Build(a))
+}
+
+// AssignExpr returns a "column=expr" set statement. For example,
+//
+//	AssignExpr("name", Expr("LOWER(?)", Ident("name")))
+//	AssignExpr("total", Expr("? * ? + ?", Ident("price"), Ident("qty"), 10))
+func AssignExpr(column string, expr Expression) Setter {
+	return exprSetter{column: column, expr: expr}
+}
+
 /// -------------------------------------------------------------------------
 
+// qualifiedSetter is the setter reading the column, whose right-hand column
+// is qualified by the target table in "ON CONFLICT ... DO UPDATE SET", where
+// both the target row and EXCLUDED are in scope.
+type qualifiedSetter interface {
+	Setter
+	qualify(table string) Setter
+}
+
+// qualifyColumn returns the quoted column qualified by the table if given.
+func qualifyColumn(a *ArgsBuilder, table, column string) string {
+	if table == "" {
+		return column
+	}
+	return a.Quote(table) + "." + column
+}
+
 type twoSetter struct {
 	format string
 	column string
+	table  string
 }
 
+func (s twoSetter) qualify(table string) Setter { s.table = table; return s }
+
 func (s twoSetter) Build(a *ArgsBuilder) string {
 	column := a.Quote(s.column)
-	return fmt.Sprintf(s.format, column, column)
+	return fmt.Sprintf(s.format, column, qualifyColumn(a, s.table, column))
 }
 
 // Incr represents SET "column = column + 1" in UPDATE.
@@ -67,12 +103,15 @@ func Decr(column string) Setter {
 type threeSetter struct {
 	format string
 	column string
+	table  string
 	value  interface{}
 }
 
+func (s threeSetter) qualify(table string) Setter { s.table = table; return s }
+
 func (s threeSetter) Build(a *ArgsBuilder) string {
 	column := a.Quote(s.column)
-	return fmt.Sprintf(s.format, column, column, a.Add(s.value))
+	return fmt.Sprintf(s.format, column, qualifyColumn(a, s.table, column), a.Add(s.value))
 }
 
 // Add represents SET "column = column + value" in UPDATE.
@@ -97,6 +136,31 @@ func Div(column string, value interface{}) Setter {
 
 /// -------------------------------------------------------------------------
 
+type insertedSetter struct {
+	column string
+}
+
+func (s insertedSetter) Build(a *ArgsBuilder) string {
+	column := a.Quote(s.column)
+	switch GetCapabilities(a.Dialect).Upsert {
+	case UpsertOnDuplicateKey:
+		return fmt.Sprintf("%s=VALUES(%s)", column, column)
+	case UpsertOnConflict:
+		return fmt.Sprintf("%s=EXCLUDED.%s", column, column)
+	default:
+		panic(unsupported(a.Dialect, "UPSERT"))
+	}
+}
+
+// Inserted represents SET "column = the inserted value of column" in UPSERT,
+// that's, "column=VALUES(column)" for MySQL and "column=EXCLUDED.column"
+// for PostgreSQL and SQLite.
+func Inserted(column string) Setter {
+	return insertedSetter{column: column}
+}
+
+/// -------------------------------------------------------------------------
+
 // SetterSet collects some UPDATE setters together.
 type SetterSet struct{}
 
@@ -110,6 +174,16 @@ func (s SetterSet) Assign(column string, value interface{}) Setter {
 	return Assign(column, value)
 }
 
+// AssignExpr is a proxy of AssignExpr.
+func (s SetterSet) AssignExpr(column string, expr Expression) Setter {
+	return AssignExpr(column, expr)
+}
+
+// Inserted is a proxy of Inserted.
+func (s SetterSet) Inserted(column string) Setter {
+	return Inserted(column)
+}
+
 // Incr is a proxy of Incr.
 func (s SetterSet) Incr(column string) Setter {
 	return Incr(column)
@@ -0,0 +1,126 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// structField is the field of the struct mapped to the column.
+type structField struct {
+	Index     int
+	Name      string // The column name, that's, the tag "sql" or the field name.
+	Table     string // The tag "table".
+	OmitEmpty bool
+}
+
+// structInfo is the metadata of the struct type, which is parsed once
+// and cached by getStructInfo.
+type structInfo struct {
+	Fields  []structField
+	Indexes map[string]int // The map from the column name to the field index.
+}
+
+var structInfos sync.Map // map[reflect.Type]*structInfo
+
+// getStructInfo returns the metadata of the struct type t, which supports
+// the tag named "sql" to modify the column name, such as `sql:"name"`
+// and `sql:"name,omitempty"`. If the value of the tag is "-", however,
+// the field will be ignored. And the unexported fields are also ignored.
+func getStructInfo(t reflect.Type) *structInfo {
+	if info, ok := structInfos.Load(t); ok {
+		return info.(*structInfo)
+	}
+
+	_len := t.NumField()
+	info := &structInfo{
+		Fields:  make([]structField, 0, _len),
+		Indexes: make(map[string]int, _len),
+	}
+
+	for i := 0; i < _len; i++ {
+		vft := t.Field(i)
+		if vft.PkgPath != "" { // Unexported
+			continue
+		}
+
+		var omitempty bool
+		name := vft.Name
+		tag := vft.Tag.Get("sql")
+		if index := strings.IndexByte(tag, ','); index > -1 {
+			if strings.TrimSpace(tag[index+1:]) == "omitempty" {
+				omitempty = true
+			}
+			tag = strings.TrimSpace(tag[:index])
+		}
+
+		if tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+
+		info.Indexes[name] = i
+		info.Fields = append(info.Fields, structField{
+			Index:     i,
+			Name:      name,
+			Table:     vft.Tag.Get("table"),
+			OmitEmpty: omitempty,
+		})
+	}
+
+	actual, _ := structInfos.LoadOrStore(t, info)
+	return actual.(*structInfo)
+}
+
+// scanPlan is the indexes of the struct fields which the columns are
+// scanned into in turn, which is computed once and reused for all the rows.
+type scanPlan []int
+
+func newScanPlan(t reflect.Type, columns []string) (scanPlan, error) {
+	info := getStructInfo(t)
+	plan := make(scanPlan, len(columns))
+	for i, column := range columns {
+		index, ok := info.Indexes[column]
+		if !ok {
+			return nil, fmt.Errorf("sqlx: %s has no field for the column '%s'", t, column)
+		}
+		plan[i] = index
+	}
+	return plan, nil
+}
+
+// Dests fills dests with the addresses of the fields of the struct v
+// in the order of the columns, and returns it.
+func (p scanPlan) Dests(v reflect.Value, dests []interface{}) []interface{} {
+	for i, index := range p {
+		dests[i] = v.Field(index).Addr().Interface()
+	}
+	return dests
+}
+
+// getStructValue returns the struct which s points to.
+func getStructValue(s interface{}) reflect.Value {
+	v := reflect.ValueOf(s)
+	if v.Kind() != reflect.Ptr {
+		panic("not a pointer to struct")
+	} else if v = v.Elem(); v.Kind() != reflect.Struct {
+		panic("not a pointer to struct")
+	}
+	return v
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type structUser struct {
+	ID      int64  `sql:"id,omitempty"`
+	Name    string `sql:"name" table:"u"`
+	Age     int    `sql:"age"`
+	Ignored string `sql:"-"`
+	Email   string
+	private int
+}
+
+func TestGetStructInfo(t *testing.T) {
+	info := getStructInfo(reflect.TypeOf(structUser{}))
+	if getStructInfo(reflect.TypeOf(structUser{})) != info {
+		t.Error("the struct metadata is not cached")
+	}
+
+	expected := "[{0 id  true} {1 name u false} {2 age  false} {4 Email  false}]"
+	if fields := fmt.Sprint(info.Fields); fields != expected {
+		t.Errorf("expect the fields '%s', but got '%s'", expected, fields)
+	}
+
+	var user structUser
+	columns := []string{"name", "id"}
+	scan := func(dests ...interface{}) error {
+		*dests[0].(*string) = "abc"
+		*dests[1].(*int64) = 123
+		return nil
+	}
+	if err := ScanColumnsToStruct(scan, columns, &user); err != nil {
+		t.Error(err)
+	} else if user.ID != 123 || user.Name != "abc" {
+		t.Errorf("unexpected user: %+v", user)
+	}
+
+	if err := ScanColumnsToStruct(scan, []string{"private"}, &user); err == nil {
+		t.Error("expect an error for the unexported field, but got nil")
+	}
+}
+
+/// --------------------------------------------------------------------------
+
+func BenchmarkRowsScanSlice(b *testing.B) {
+	rows := make([][]driver.Value, 1000)
+	for i := range rows {
+		rows[i] = []driver.Value{int64(i), "name", int64(18), "email"}
+	}
+
+	d := &testDriver{Columns: []string{"id", "name", "age", "Email"},
+		Results: [][][]driver.Value{rows}, Repeat: true}
+	db := d.DB(MySQL)
+	defer db.Close()
+
+	query := db.Selects("id", "name", "age", "Email").From("users")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var users []structUser
+		if err := query.BindRows(&users); err != nil {
+			b.Fatal(err)
+		} else if len(users) != 1000 {
+			b.Fatalf("expect 1000 users, but got %d", len(users))
+		}
+	}
+}
+
+func BenchmarkScanColumnsToStruct(b *testing.B) {
+	var user structUser
+	columns := []string{"id", "name", "age", "Email"}
+	scan := func(...interface{}) error { return nil }
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := ScanColumnsToStruct(scan, columns, &user); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSelectStruct(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		SelectStruct(structUser{})
+	}
+}
+
+func BenchmarkInsertBuilderStruct(b *testing.B) {
+	user := structUser{ID: 1, Name: "name", Age: 18, Email: "email"}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Insert().Into("users").Struct(user)
+	}
+}
@@ -0,0 +1,11 @@
+module github.com/xgfone/sqlx/typed
+
+go 1.18
+
+// The typed helpers require the sqlx commit containing BuildError.
+require github.com/xgfone/sqlx v0.0.0-20261016121700-940bbf7cf4f2
+
+require (
+	github.com/opentracing/opentracing-go v1.2.0 // indirect
+	github.com/xgfone/cast v0.5.0 // indirect
+)
@@ -0,0 +1,129 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package typed provides the generic helpers on top of the SQL builders
+// of sqlx, which return the typed values instead of scanning them into
+// interface{}, and requires Go1.18+. For example,
+//
+//	type User struct {
+//		ID   int64  `sql:"id"`
+//		Name string `sql:"name"`
+//	}
+//
+//	users, err := typed.QueryAll[User](ctx, db.Select("id").Select("name").From("users"))
+//	user, err := typed.QueryOne[User](ctx, db.Select("id").Select("name").From("users").Where(sqlx.Equal("id", 123)))
+//	ids, err := typed.QueryAll[int64](ctx, db.Select("id").From("users"))
+//	result, err := typed.Insert(ctx, db.Insert().Into("users"), User{Name: "a"}, User{Name: "b"})
+//
+// The mapping between the columns and the struct fields is that of sqlx,
+// that's, the tag named "sql", such as sqlx.ScanColumnsToStruct.
+package typed
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/xgfone/sqlx"
+)
+
+// QueryAll executes the SELECT query and scans all the rows into []T.
+//
+// If T is a struct, the selected columns are scanned into its fields
+// by sqlx.Rows.ScanSlice. Or, T must be the type supported by sql.Rows.Scan,
+// such as int64, string, time.Time and sql.Scanner, and only one column
+// should be selected.
+func QueryAll[T any](ctx context.Context, q *sqlx.SelectBuilder) ([]T, error) {
+	rows, err := q.QueryContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []T
+	if isStruct[T]() {
+		if err = rows.ScanSlice(&values); err != nil {
+			return nil, err
+		}
+		return values, rows.Err()
+	}
+
+	for rows.Next() {
+		var value T
+		if err = rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+
+	return values, rows.Err()
+}
+
+// QueryOne is the same as QueryAll, but only scans the first row,
+// which returns sql.ErrNoRows if there is no row.
+func QueryOne[T any](ctx context.Context, q *sqlx.SelectBuilder) (value T, err error) {
+	row := q.QueryRowContext(ctx)
+	if isStruct[T]() {
+		err = row.ScanStruct(&value)
+	} else {
+		err = row.Scan(&value)
+	}
+	return
+}
+
+// Insert inserts the rows by the INSERT builder b with sqlx.InsertBuilder.Structs,
+// and returns the result. T may be also a pointer to struct, and the nil rows
+// are skipped. If the columns of b have been set, only they are inserted.
+//
+// Notice: like sqlx.InsertBuilder.Structs, the field with the tag "omitempty"
+// is omitted only if it is zero in all the rows. For a large number of rows,
+// use sqlx.InsertBuilder.ExecBatch instead.
+func Insert[T any](ctx context.Context, b *sqlx.InsertBuilder, rows ...T) (sql.Result, error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	isPtr := t.Kind() == reflect.Ptr
+	if isPtr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("typed: %s is not a struct", t)
+	}
+
+	values := len(rows)
+	if isPtr {
+		for _, row := range rows {
+			if reflect.ValueOf(row).IsNil() {
+				values--
+			}
+		}
+	}
+
+	if values == 0 {
+		return nil, sqlx.BuildError{Builder: "InsertBuilder", Err: sqlx.ErrNoValues}
+	}
+	return b.Structs(rows).ExecContext(ctx)
+}
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+)
+
+// isStruct reports whether T is a struct whose fields the columns are
+// scanned into, but not time.Time or sql.Scanner.
+func isStruct[T any]() bool {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	return t.Kind() == reflect.Struct && t != timeType && !reflect.PtrTo(t).Implements(scannerType)
+}
@@ -0,0 +1,160 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package typed
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/xgfone/sqlx"
+)
+
+// fakeDriver is a fake driver, which returns the canned rows for each query,
+// and records the executed statements.
+type fakeDriver struct {
+	rows  [][][]driver.Value
+	execs []string
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{d}, nil }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{c.d, query}, nil }
+func (c fakeConn) Close() error                              { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)                 { return nil, driver.ErrSkip }
+
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.execs = append(s.d.execs, fmt.Sprintf("%s %v", s.query, args))
+	return driver.RowsAffected(1), nil
+}
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	rows := s.d.rows[0]
+	s.d.rows = s.d.rows[1:]
+	return &fakeRows{rows: rows}, nil
+}
+
+type fakeRows struct{ rows [][]driver.Value }
+
+func (r *fakeRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return []string{"id", "name"}
+	}
+	return []string{"id", "name"}[:len(r.rows[0])]
+}
+func (r *fakeRows) Close() error { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+type user struct {
+	ID      int64  `sql:"id"`
+	Name    string `sql:"name"`
+	Ignored string `sql:"-"`
+	private int
+}
+
+func TestTyped(t *testing.T) {
+	d := &fakeDriver{rows: [][][]driver.Value{
+		{{int64(1), "a"}, {int64(2), "b"}},
+		{{int64(3)}, {int64(4)}},
+		{{int64(5), "c"}},
+		{},
+		{},
+	}}
+	sql.Register("typed-fake", d)
+	sqldb, err := sql.Open("typed-fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqldb.Close()
+
+	db := &sqlx.DB{DB: sqldb, Dialect: sqlx.MySQL}
+	ctx := context.Background()
+
+	users, err := QueryAll[user](ctx, db.Selects("id", "name").From("users"))
+	if err != nil {
+		t.Fatal(err)
+	} else if fmt.Sprint(users) != "[{1 a  0} {2 b  0}]" {
+		t.Errorf("unexpected users: %v", users)
+	}
+
+	ids, err := QueryAll[int64](ctx, db.Select("id").From("users"))
+	if err != nil {
+		t.Fatal(err)
+	} else if fmt.Sprint(ids) != "[3 4]" {
+		t.Errorf("unexpected ids: %v", ids)
+	}
+
+	u, err := QueryOne[user](ctx, db.Selects("id", "name").From("users"))
+	if err != nil {
+		t.Fatal(err)
+	} else if u.ID != 5 || u.Name != "c" {
+		t.Errorf("unexpected user: %v", u)
+	}
+
+	if _, err = QueryOne[user](ctx, db.Selects("id", "name").From("users")); err != sql.ErrNoRows {
+		t.Errorf("expect sql.ErrNoRows, but got %v", err)
+	}
+
+	if _, err = QueryAll[user](ctx, db.Selects("id", "age").From("users")); err == nil {
+		t.Error("expect an error for the unknown column, but got nil")
+	}
+
+	var be sqlx.BuildError
+	if _, err = QueryAll[user](ctx, db.Select("id")); !errors.As(err, &be) || be.Err != sqlx.ErrNoTable {
+		t.Errorf("expect the error ErrNoTable, but got %v", err)
+	}
+
+	_, err = Insert(ctx, db.Insert().Into("users"), user{ID: 1, Name: "a"}, user{ID: 2, Name: "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = Insert(ctx, db.Insert().Into("users"), &user{ID: 3, Name: "c"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = Insert(ctx, db.Insert().Into("users").Columns("name"), user{ID: 4, Name: "d"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = Insert[*user](ctx, db.Insert().Into("users"), nil); !errors.Is(err, sqlx.ErrNoValues) {
+		t.Errorf("expect the error ErrNoValues, but got %v", err)
+	}
+
+	expected := "[INSERT INTO `users` (`id`, `name`) VALUES (?, ?), (?, ?) [1 a 2 b] " +
+		"INSERT INTO `users` (`id`, `name`) VALUES (?, ?) [3 c] " +
+		"INSERT INTO `users` (`name`) VALUES (?) [d]]"
+	if execs := fmt.Sprint(d.execs); execs != expected {
+		t.Errorf("expect '%s', but got '%s'", expected, execs)
+	}
+}
@@ -49,10 +49,15 @@ type UpdateBuilder struct {
 	joins     []joinTable
 	where     []Condition
 	setters   []Setter
+
+	returnings []string
+
+	immutable bool
 }
 
 // Table appends the table name.
 func (b *UpdateBuilder) Table(table string, alias ...string) *UpdateBuilder {
+	b = b.writable()
 	if table != "" {
 		var talias string
 		if len(alias) != 0 {
@@ -64,7 +69,11 @@ func (b *UpdateBuilder) Table(table string, alias ...string) *UpdateBuilder {
 }
 
 // From appends the from table name.
+//
+// For the dialect supporting UpdateJoin, such as MySQL, the from tables
+// are appended to the updated tables, that's, "UPDATE t1, t2 SET ...".
 func (b *UpdateBuilder) From(table string, alias ...string) *UpdateBuilder {
+	b = b.writable()
 	if table != "" {
 		var talias string
 		if len(alias) != 0 {
@@ -75,55 +84,120 @@ func (b *UpdateBuilder) From(table string, alias ...string) *UpdateBuilder {
 	return b
 }
 
+// Join appends the "JOIN table ON on..." statement, such as On("t1.id", "t2.tid").
+//
+// For the general conditions, such as Equal("t2.type", 1), use JoinCond instead.
+func (b *UpdateBuilder) Join(table, alias string, ons ...JoinOn) *UpdateBuilder {
+	return b.joinTable("", table, alias, joinOns(ons))
+}
+
+// JoinCond appends the "JOIN table ON cond..." statement, which conds may be
+// any Condition, such as On("t1.id", "t2.tid") and Equal("t2.type", 1),
+// and are joined by AND.
+func (b *UpdateBuilder) JoinCond(table, alias string, conds ...Condition) *UpdateBuilder {
+	return b.joinTable("", table, alias, conds)
+}
+
 // JoinLeft appends the "LEFT JOIN table ON on..." statement.
 func (b *UpdateBuilder) JoinLeft(table, alias string, ons ...JoinOn) *UpdateBuilder {
-	return b.joinTable("LEFT", table, alias, ons...)
+	return b.joinTable("LEFT", table, alias, joinOns(ons))
+}
+
+// JoinLeftCond appends the "LEFT JOIN table ON cond..." statement like JoinCond.
+func (b *UpdateBuilder) JoinLeftCond(table, alias string, conds ...Condition) *UpdateBuilder {
+	return b.joinTable("LEFT", table, alias, conds)
 }
 
 // JoinLeftOuter appends the "LEFT OUTER JOIN table ON on..." statement.
 func (b *UpdateBuilder) JoinLeftOuter(table, alias string, ons ...JoinOn) *UpdateBuilder {
-	return b.joinTable("LEFT OUTER", table, alias, ons...)
+	return b.joinTable("LEFT OUTER", table, alias, joinOns(ons))
+}
+
+// JoinLeftOuterCond appends the "LEFT OUTER JOIN table ON cond..." statement like JoinCond.
+func (b *UpdateBuilder) JoinLeftOuterCond(table, alias string, conds ...Condition) *UpdateBuilder {
+	return b.joinTable("LEFT OUTER", table, alias, conds)
 }
 
 // JoinRight appends the "RIGHT JOIN table ON on..." statement.
 func (b *UpdateBuilder) JoinRight(table, alias string, ons ...JoinOn) *UpdateBuilder {
-	return b.joinTable("RIGHT", table, alias, ons...)
+	return b.joinTable("RIGHT", table, alias, joinOns(ons))
+}
+
+// JoinRightCond appends the "RIGHT JOIN table ON cond..." statement like JoinCond.
+func (b *UpdateBuilder) JoinRightCond(table, alias string, conds ...Condition) *UpdateBuilder {
+	return b.joinTable("RIGHT", table, alias, conds)
 }
 
 // JoinRightOuter appends the "RIGHT OUTER JOIN table ON on..." statement.
 func (b *UpdateBuilder) JoinRightOuter(table, alias string, ons ...JoinOn) *UpdateBuilder {
-	return b.joinTable("RIGHT OUTER", table, alias, ons...)
+	return b.joinTable("RIGHT OUTER", table, alias, joinOns(ons))
+}
+
+// JoinRightOuterCond appends the "RIGHT OUTER JOIN table ON cond..." statement like JoinCond.
+func (b *UpdateBuilder) JoinRightOuterCond(table, alias string, conds ...Condition) *UpdateBuilder {
+	return b.joinTable("RIGHT OUTER", table, alias, conds)
 }
 
 // JoinFull appends the "FULL JOIN table ON on..." statement.
 func (b *UpdateBuilder) JoinFull(table, alias string, ons ...JoinOn) *UpdateBuilder {
-	return b.joinTable("FULL", table, alias, ons...)
+	return b.joinTable("FULL", table, alias, joinOns(ons))
+}
+
+// JoinFullCond appends the "FULL JOIN table ON cond..." statement like JoinCond.
+func (b *UpdateBuilder) JoinFullCond(table, alias string, conds ...Condition) *UpdateBuilder {
+	return b.joinTable("FULL", table, alias, conds)
 }
 
 // JoinFullOuter appends the "FULL OUTER JOIN table ON on..." statement.
 func (b *UpdateBuilder) JoinFullOuter(table, alias string, ons ...JoinOn) *UpdateBuilder {
-	return b.joinTable("FULL OUTER", table, alias, ons...)
+	return b.joinTable("FULL OUTER", table, alias, joinOns(ons))
+}
+
+// JoinFullOuterCond appends the "FULL OUTER JOIN table ON cond..." statement like JoinCond.
+func (b *UpdateBuilder) JoinFullOuterCond(table, alias string, conds ...Condition) *UpdateBuilder {
+	return b.joinTable("FULL OUTER", table, alias, conds)
 }
 
-func (b *UpdateBuilder) joinTable(cmd, table, alias string, ons ...JoinOn) *UpdateBuilder {
+// JoinUsing appends the "JOIN table USING (columns...)" statement.
+func (b *UpdateBuilder) JoinUsing(table, alias string, columns ...string) *UpdateBuilder {
+	b = b.writable()
+	b.joins = append(b.joins, joinTable{Table: table, Alias: alias, Using: columns})
+	return b
+}
+
+// JoinCross appends the "CROSS JOIN table" statement.
+func (b *UpdateBuilder) JoinCross(table, alias string) *UpdateBuilder {
+	return b.joinTable("CROSS", table, alias, nil)
+}
+
+// JoinNatural appends the "NATURAL JOIN table" statement.
+func (b *UpdateBuilder) JoinNatural(table, alias string) *UpdateBuilder {
+	return b.joinTable("NATURAL", table, alias, nil)
+}
+
+func (b *UpdateBuilder) joinTable(cmd, table, alias string, ons []Condition) *UpdateBuilder {
+	b = b.writable()
 	b.joins = append(b.joins, joinTable{Type: cmd, Table: table, Alias: alias, Ons: ons})
 	return b
 }
 
 // Set resets the SET statement to setters.
 func (b *UpdateBuilder) Set(setters ...Setter) *UpdateBuilder {
+	b = b.writable()
 	b.setters = setters
 	return b
 }
 
 // SetMore appends the setters to the current SET statements.
 func (b *UpdateBuilder) SetMore(setters ...Setter) *UpdateBuilder {
+	b = b.writable()
 	b.setters = append(b.setters, setters...)
 	return b
 }
 
 // SetNamedArg is the same as Set, but uses the NamedArg as the Setter.
 func (b *UpdateBuilder) SetNamedArg(args ...NamedArg) *UpdateBuilder {
+	b = b.writable()
 	b.setters = make([]Setter, len(args))
 	for i, arg := range args {
 		b.setters[i] = Set(arg.Name(), arg.Get())
@@ -133,26 +207,66 @@ func (b *UpdateBuilder) SetNamedArg(args ...NamedArg) *UpdateBuilder {
 
 // SetMoreNamedArg is the same as SetMore, but uses the NamedArg as the Setter.
 func (b *UpdateBuilder) SetMoreNamedArg(args ...NamedArg) *UpdateBuilder {
-	for _, arg := range args {
-		b.SetMore(Set(arg.Name(), arg.Get()))
+	setters := make([]Setter, len(args))
+	for i, arg := range args {
+		setters[i] = Set(arg.Name(), arg.Get())
 	}
-	return b
+	return b.SetMore(setters...)
 }
 
 // WhereNamedArgs is the same as Where, but uses the NamedArg as the condition.
 func (b *UpdateBuilder) WhereNamedArgs(args ...NamedArg) *UpdateBuilder {
-	for _, arg := range args {
-		b.Where(b.Equal(arg.Name(), arg.Get()))
+	conds := make([]Condition, len(args))
+	for i, arg := range args {
+		conds[i] = b.Equal(arg.Name(), arg.Get())
 	}
-	return b
+	return b.Where(conds...)
 }
 
 // Where sets the WHERE conditions.
 func (b *UpdateBuilder) Where(andConditions ...Condition) *UpdateBuilder {
+	b = b.writable()
 	b.where = append(b.where, andConditions...)
 	return b
 }
 
+// Returning sets the columns returned by the UPDATE statement,
+// that's, "RETURNING columns..." for PostgreSQL and SQLite,
+// and "OUTPUT INSERTED.column..." for SQL Server.
+//
+// Use Query or QueryRow instead of Exec to get the returned rows.
+func (b *UpdateBuilder) Returning(columns ...string) *UpdateBuilder {
+	b = b.writable()
+	b.returnings = columns
+	return b
+}
+
+// Query builds the sql and executes it by *sql.DB, which is used
+// with Returning.
+func (b *UpdateBuilder) Query() (Rows, error) {
+	return b.QueryContext(context.Background())
+}
+
+// QueryContext builds the sql and executes it by *sql.DB, which is used
+// with Returning.
+func (b *UpdateBuilder) QueryContext(ctx context.Context) (Rows, error) {
+	query, args, err := b.BuildE()
+	return returningBuilder{b.executor, b.returnings}.Query(ctx, query, args, err)
+}
+
+// QueryRow builds the sql and executes it by *sql.DB, which is used
+// with Returning.
+func (b *UpdateBuilder) QueryRow() Row {
+	return b.QueryRowContext(context.Background())
+}
+
+// QueryRowContext builds the sql and executes it by *sql.DB, which is used
+// with Returning.
+func (b *UpdateBuilder) QueryRowContext(ctx context.Context) Row {
+	query, args, err := b.BuildE()
+	return returningBuilder{b.executor, b.returnings}.QueryRow(ctx, query, args, err)
+}
+
 // Exec builds the sql and executes it by *sql.DB.
 func (b *UpdateBuilder) Exec() (sql.Result, error) {
 	return b.ExecContext(context.Background())
@@ -160,28 +274,72 @@ func (b *UpdateBuilder) Exec() (sql.Result, error) {
 
 // ExecContext builds the sql and executes it by *sql.DB.
 func (b *UpdateBuilder) ExecContext(ctx context.Context) (sql.Result, error) {
-	query, args := b.Build()
+	query, args, err := b.BuildE()
+	if err != nil {
+		return nil, err
+	}
 	return b.executor.ExecContext(ctx, query, args...)
 }
 
 // SetExecutor sets the executor to exec.
 func (b *UpdateBuilder) SetExecutor(exec Executor) *UpdateBuilder {
+	b = b.writable()
 	b.executor = exec
 	return b
 }
 
 // SetInterceptor sets the interceptor to f.
 func (b *UpdateBuilder) SetInterceptor(f Interceptor) *UpdateBuilder {
+	b = b.writable()
 	b.intercept = f
 	return b
 }
 
 // SetDialect resets the dialect.
 func (b *UpdateBuilder) SetDialect(dialect Dialect) *UpdateBuilder {
+	b = b.writable()
 	b.dialect = dialect
 	return b
 }
 
+// Clone returns a deep copy of the builder, which can be modified without
+// affecting the original, but the conditions and the setters are shared.
+// The returned builder is always mutable even if b is immutable.
+func (b *UpdateBuilder) Clone() *UpdateBuilder {
+	c := *b
+	c.immutable = false
+	c.ftables = cloneTables(b.ftables)
+	c.tables = cloneTables(b.tables)
+	c.joins = cloneJoins(b.joins)
+	c.where = append([]Condition(nil), b.where...)
+	c.setters = append([]Setter(nil), b.setters...)
+	c.returnings = append([]string(nil), b.returnings...)
+	return &c
+}
+
+// Immutable returns an immutable clone of the builder, which is copied
+// on write like SelectBuilder.Immutable.
+func (b *UpdateBuilder) Immutable() *UpdateBuilder {
+	c := b.Clone()
+	c.immutable = true
+	return c
+}
+
+func (b *UpdateBuilder) writable() *UpdateBuilder {
+	if b.immutable {
+		return b.Immutable()
+	}
+	return b
+}
+
+// BuildE is the same as Build, but returns the error instead of panicking,
+// which is BuildError or UnsupportedError.
+func (b *UpdateBuilder) BuildE() (sql string, args []interface{}, err error) {
+	defer recoverBuildError(&err)
+	sql, args = b.Build()
+	return
+}
+
 // String is the same as b.Build(), except args.
 func (b *UpdateBuilder) String() string {
 	sql, _ := b.Build()
@@ -191,9 +349,9 @@ func (b *UpdateBuilder) String() string {
 // Build builds the UPDATE sql statement.
 func (b *UpdateBuilder) Build() (sql string, args []interface{}) {
 	if len(b.tables) == 0 {
-		panic("UpdateBuilder: no table name")
+		panic(buildError("UpdateBuilder", ErrNoTable))
 	} else if len(b.setters) == 0 {
-		panic("UpdateBuilder: no set values")
+		panic(buildErrorf("UpdateBuilder", ErrNoValues, "no set values"))
 	}
 
 	dialect := b.dialect
@@ -201,46 +359,83 @@ func (b *UpdateBuilder) Build() (sql string, args []interface{}) {
 		dialect = DefaultDialect
 	}
 
+	tables, ftables := b.tables, b.ftables
+	var target string
+	var joinAfterFrom bool
+	switch GetCapabilities(dialect).Update {
+	case UpdateGeneric:
+	case UpdateJoin:
+		if len(ftables) > 0 {
+			tables = append(tables[:len(tables):len(tables)], ftables...)
+			ftables = nil
+		}
+	case UpdateFrom:
+		if len(tables) > 1 {
+			panic(unsupported(dialect, "UPDATE with multiple tables"))
+		} else if len(b.joins) > 0 {
+			panic(unsupported(dialect, "JOIN in UPDATE"))
+		}
+	case UpdateFromJoin:
+		if len(tables) > 1 {
+			panic(unsupported(dialect, "UPDATE with multiple tables"))
+		} else if len(b.joins) > 0 || len(ftables) > 0 {
+			if target = tables[0].Alias; target == "" {
+				target = tables[0].Table
+			}
+			ftables = append(tables[:1:1], ftables...)
+			joinAfterFrom = true
+		}
+	default:
+		if len(tables) > 1 || len(ftables) > 0 || len(b.joins) > 0 {
+			panic(unsupported(dialect, "UPDATE with multiple tables"))
+		}
+	}
+
 	// Update Table
+	ab := NewArgsBuilder(dialect)
 	buf := getBuffer()
 	buf.WriteString("UPDATE ")
-	for i, t := range b.tables {
-		if i > 0 {
-			buf.WriteString(", ")
-		}
-		buf.WriteString(dialect.Quote(t.Table))
-		if t.Alias != "" {
-			buf.WriteString(" AS ")
-			buf.WriteString(dialect.Quote(t.Alias))
+	if target != "" {
+		buf.WriteString(dialect.Quote(target))
+	} else {
+		for i, t := range tables {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			t.Build(buf, ab)
 		}
 	}
 
 	// Join
-	for _, join := range b.joins {
-		join.Build(buf, dialect)
+	if !joinAfterFrom {
+		for _, join := range b.joins {
+			join.Build(buf, ab)
+		}
 	}
 
 	// Set
 	buf.WriteString(" SET ")
-	ab := NewArgsBuilder(dialect)
 	for i, setter := range b.setters {
 		if i > 0 {
 			buf.WriteString(", ")
 		}
 		buf.WriteString(setter.Build(ab))
 	}
+	addOutput(buf, dialect, "INSERTED", b.returnings)
 
 	// From
-	for i, t := range b.ftables {
+	for i, t := range ftables {
 		if i == 0 {
 			buf.WriteString(" FROM ")
 		} else {
 			buf.WriteString(", ")
 		}
-		buf.WriteString(dialect.Quote(t.Table))
-		if t.Alias != "" {
-			buf.WriteString(" AS ")
-			buf.WriteString(dialect.Quote(t.Alias))
+		t.Build(buf, ab)
+	}
+
+	if joinAfterFrom {
+		for _, join := range b.joins {
+			join.Build(buf, ab)
 		}
 	}
 
@@ -254,6 +449,7 @@ func (b *UpdateBuilder) Build() (sql string, args []interface{}) {
 		buf.WriteString(" WHERE ")
 		buf.WriteString(expr.Build(ab))
 	}
+	addReturning(buf, dialect, b.returnings)
 
 	sql = buf.String()
 	args = ab.Args()
@@ -41,3 +41,39 @@ func ExampleUpdateBuilder() {
 	// UPDATE "table" SET "c2"="c2"-1 WHERE "c3"=$1
 	// [789]
 }
+
+func ExampleUpdateBuilder_JoinCond() {
+	update := Update().Table("users", "u").
+		JoinCond("orders", "o", On("o.user_id", "u.id"), Greater("o.amount", 100)).
+		Set(Assign("u.level", 2)).Where(Equal("u.status", 1))
+
+	sql1, args1 := update.SetDialect(MySQL).Build()
+	sql2, args2 := update.SetDialect(MSSQL).Build()
+
+	fmt.Println(sql1)
+	fmt.Println(args1)
+
+	fmt.Println(sql2)
+	fmt.Println(args2)
+
+	// Output:
+	// UPDATE `users` AS `u` JOIN `orders` AS `o` ON `o`.`user_id`=`u`.`id` AND `o`.`amount`>? SET `u`.`level`=? WHERE `u`.`status`=?
+	// [100 2 1]
+	// UPDATE [u] SET [u].[level]=@p1 FROM [users] AS [u] JOIN [orders] AS [o] ON [o].[user_id]=[u].[id] AND [o].[amount]>@p2 WHERE [u].[status]=@p3
+	// [2 100 1]
+}
+
+func ExampleUpdateBuilder_Immutable() {
+	base := Update().Table("users").Set(Assign("status", 0)).Where(Less("age", 18)).Immutable()
+	u1 := base.SetMore(Assign("name", "minor")).Where(Equal("area", "a"))
+	u2 := base.Where(Equal("area", "b")).Returning("id")
+
+	fmt.Println(base)
+	fmt.Println(u1)
+	fmt.Println(u2.SetDialect(Postgres))
+
+	// Output:
+	// UPDATE `users` SET `status`=? WHERE `age`<?
+	// UPDATE `users` SET `status`=?, `name`=? WHERE (`age`<? AND `area`=?)
+	// UPDATE "users" SET "status"=$1 WHERE ("age"<$2 AND "area"=$3) RETURNING "id"
+}
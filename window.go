@@ -0,0 +1,196 @@
+// Copyright 2020 xgfone
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlx
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// FrameBound is the bound of the window frame used by Window.Rows and
+// Window.Range, which is only built by UnboundedPreceding, Preceding,
+// CurrentRow, Following and UnboundedFollowing.
+type FrameBound struct{ bound string }
+
+// Predefine some bounds of the window frame.
+var (
+	UnboundedPreceding = FrameBound{"UNBOUNDED PRECEDING"}
+	UnboundedFollowing = FrameBound{"UNBOUNDED FOLLOWING"}
+	CurrentRow         = FrameBound{"CURRENT ROW"}
+)
+
+// Preceding returns the bound of the window frame "n PRECEDING".
+func Preceding(n int) FrameBound { return FrameBound{fmt.Sprintf("%d PRECEDING", n)} }
+
+// Following returns the bound of the window frame "n FOLLOWING".
+func Following(n int) FrameBound { return FrameBound{fmt.Sprintf("%d FOLLOWING", n)} }
+
+// Window is the window specification used by OVER and WINDOW,
+// whose columns are quoted by the dialect.
+type Window struct {
+	base       string
+	partitions []string
+	orderbys   []orderby
+	frame      string
+}
+
+// NewWindow returns a new window specification.
+//
+// If base is given, the window is based on the named window defined by
+// SelectBuilder.Window, that's, "OVER (base ...)", or "OVER base" if there
+// are no other specifications.
+func NewWindow(base ...string) *Window {
+	w := &Window{}
+	if len(base) > 0 {
+		w.base = base[0]
+	}
+	return w
+}
+
+// PartitionBy appends the columns used by PARTITION BY.
+func (w *Window) PartitionBy(columns ...string) *Window {
+	w.partitions = append(w.partitions, columns...)
+	return w
+}
+
+// OrderBy appends the column used by ORDER BY.
+func (w *Window) OrderBy(column string, order ...Order) *Window {
+	ob := orderby{Column: column}
+	if len(order) > 0 {
+		ob.Order = order[0]
+	}
+	w.orderbys = append(w.orderbys, ob)
+	return w
+}
+
+// OrderByDesc appends the column used by ORDER BY DESC.
+func (w *Window) OrderByDesc(column string) *Window {
+	return w.OrderBy(column, Desc)
+}
+
+// OrderByAsc appends the column used by ORDER BY ASC.
+func (w *Window) OrderByAsc(column string) *Window {
+	return w.OrderBy(column, Asc)
+}
+
+// Rows sets the window frame "ROWS BETWEEN start AND end". For example,
+//
+//	NewWindow().OrderBy("id").Rows(UnboundedPreceding, CurrentRow)
+//	NewWindow().OrderBy("id").Rows(Preceding(2), Following(2))
+func (w *Window) Rows(start, end FrameBound) *Window {
+	w.frame = fmt.Sprintf("ROWS BETWEEN %s AND %s", start.bound, end.bound)
+	return w
+}
+
+// Range is the same as Rows, but uses "RANGE BETWEEN start AND end".
+func (w *Window) Range(start, end FrameBound) *Window {
+	w.frame = fmt.Sprintf("RANGE BETWEEN %s AND %s", start.bound, end.bound)
+	return w
+}
+
+// Clone returns a copy of the window specification.
+func (w *Window) Clone() *Window {
+	c := *w
+	c.partitions = append([]string(nil), w.partitions...)
+	c.orderbys = append([]orderby(nil), w.orderbys...)
+	return &c
+}
+
+func (w *Window) isNamed() bool {
+	return w.base != "" && len(w.partitions) == 0 && len(w.orderbys) == 0 &&
+		w.frame == ""
+}
+
+// build builds the window specification enclosed in parentheses.
+//
+// If over is true and the window only refers to the named window,
+// build the name only.
+func (w *Window) build(buf *bytes.Buffer, ab *ArgsBuilder, over bool) {
+	dialect := ab.Dialect
+	if over && w.isNamed() {
+		buf.WriteString(dialect.Quote(w.base))
+		return
+	}
+
+	var space bool
+	buf.WriteByte('(')
+	if w.base != "" {
+		buf.WriteString(dialect.Quote(w.base))
+		space = true
+	}
+
+	if len(w.partitions) > 0 {
+		if space {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString("PARTITION BY ")
+		for i, column := range w.partitions {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(dialect.Quote(column))
+		}
+		space = true
+	}
+
+	if len(w.orderbys) > 0 {
+		if space {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString("ORDER BY ")
+		for i, ob := range w.orderbys {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			buf.WriteString(ob.Build(ab))
+			if ob.Order != "" {
+				buf.WriteByte(' ')
+				buf.WriteString(string(ob.Order))
+			}
+		}
+		space = true
+	}
+
+	if w.frame != "" {
+		if space {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(w.frame)
+	}
+	buf.WriteByte(')')
+}
+
+type namedWindow struct {
+	Name   string
+	Window *Window
+}
+
+func buildWindows(buf *bytes.Buffer, ab *ArgsBuilder, windows []namedWindow) {
+	if len(windows) > 0 && !GetCapabilities(ab.Dialect).NamedWindow {
+		panic(unsupported(ab.Dialect, "WINDOW"))
+	}
+
+	for i, w := range windows {
+		if i == 0 {
+			buf.WriteString(" WINDOW ")
+		} else {
+			buf.WriteString(", ")
+		}
+
+		buf.WriteString(ab.Quote(w.Name))
+		buf.WriteString(" AS ")
+		w.Window.build(buf, ab, false)
+	}
+}